@@ -0,0 +1,82 @@
+// Command mockapi is a local stand-in for the shop backend. It speaks the
+// same single-endpoint protocol as the live API: a POST of
+// {type, operation, params} answered with {data, count, error}.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"terminal-echoware/internal/api"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	fixtures := flag.String("fixtures", "fixtures", "directory holding products.json and categories.json")
+	latency := flag.Duration("latency", 0, "delay added to every response")
+	jitter := flag.Duration("jitter", 0, "random extra delay up to this duration")
+	errorRate := flag.Float64("error-rate", 0, "fraction of requests (0..1) answered with an injected error")
+	errorStatus := flag.Int("error-status", http.StatusServiceUnavailable, "HTTP status used for injected errors")
+	errorOps := flag.String("error-ops", "", "comma-separated operations eligible for error injection (default: all)")
+	flag.Parse()
+
+	backend, err := api.LoadMemoryBackend(*fixtures)
+	if err != nil {
+		log.Fatalf("load fixtures: %v", err)
+	}
+
+	srv := &server{
+		backend: backend,
+		faults: faults{
+			latency:     *latency,
+			jitter:      *jitter,
+			errorRate:   *errorRate,
+			errorStatus: *errorStatus,
+			errorOps:    splitOps(*errorOps),
+		},
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+
+	log.Printf("Mock API serving %s on %s", *fixtures, *addr)
+
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	<-done
+	log.Println("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func splitOps(s string) map[string]bool {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	ops := make(map[string]bool)
+	for _, op := range strings.Split(s, ",") {
+		if op = strings.TrimSpace(op); op != "" {
+			ops[op] = true
+		}
+	}
+	return ops
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"terminal-echoware/internal/api"
+	"terminal-echoware/pkg/types"
+	"time"
+)
+
+// rpcRequest mirrors types.APIRequest but keeps params raw so each operation
+// can decode them into its own params type.
+type rpcRequest struct {
+	Type      types.OperationType `json:"type"`
+	Operation string              `json:"operation"`
+	Params    json.RawMessage     `json:"params"`
+}
+
+// rpcResponse mirrors types.APIResponse with Data left as a value to encode.
+type rpcResponse struct {
+	Data  any    `json:"data"`
+	Count int    `json:"count,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type faults struct {
+	latency     time.Duration
+	jitter      time.Duration
+	errorRate   float64
+	errorStatus int
+	errorOps    map[string]bool // nil means every operation
+}
+
+func (f faults) delay() time.Duration {
+	d := f.latency
+	if f.jitter > 0 {
+		d += rand.N(f.jitter)
+	}
+	return d
+}
+
+func (f faults) shouldFail(operation string) bool {
+	if f.errorRate <= 0 {
+		return false
+	}
+	if f.errorOps != nil && !f.errorOps[operation] {
+		return false
+	}
+	return rand.Float64() < f.errorRate
+}
+
+type server struct {
+	backend *api.MemoryBackend
+	faults  faults
+}
+
+// errBadRequest marks failures caused by the request itself.
+var errBadRequest = errors.New("bad request")
+
+type handlerFunc func(s *server, r *http.Request, params json.RawMessage) (rpcResponse, error)
+
+type route struct {
+	typ     types.OperationType
+	handler handlerFunc
+}
+
+var routes = map[string]route{
+	"product.list":        {types.OperationTypeQuery, handleProductList},
+	"product.get":         {types.OperationTypeQuery, handleProductGet},
+	"product.search":      {types.OperationTypeQuery, handleProductSearch},
+	"category.list":       {types.OperationTypeQuery, handleCategoryList},
+	"category.get":        {types.OperationTypeQuery, handleCategoryGet},
+	"category.create":     {types.OperationTypeMutation, handleCategoryCreate},
+	"category.update":     {types.OperationTypeMutation, handleCategoryUpdate},
+	"category.delete":     {types.OperationTypeMutation, handleCategoryDelete},
+	"product.create":      {types.OperationTypeMutation, handleProductCreate},
+	"product.update":      {types.OperationTypeMutation, handleProductUpdate},
+	"product.delete":      {types.OperationTypeMutation, handleProductDelete},
+	"order.create":        {types.OperationTypeMutation, handleOrderCreate},
+	"order.list":          {types.OperationTypeQuery, handleOrderList},
+	"order.get":           {types.OperationTypeQuery, handleOrderGet},
+	"order.update_status": {types.OperationTypeMutation, handleOrderUpdateStatus},
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeResponse(w, http.StatusMethodNotAllowed, rpcResponse{Error: "method not allowed"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, rpcResponse{Error: "read request: " + err.Error()})
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeResponse(w, http.StatusBadRequest, rpcResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	if d := s.faults.delay(); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status, resp := s.dispatch(r, req)
+	writeResponse(w, status, resp)
+	log.Printf("%s %s -> %d (%s)", req.Type, req.Operation, status, time.Since(start).Round(time.Millisecond))
+}
+
+func (s *server) dispatch(r *http.Request, req rpcRequest) (int, rpcResponse) {
+	rt, ok := routes[req.Operation]
+	if !ok {
+		return http.StatusBadRequest, rpcResponse{Error: fmt.Sprintf("unknown operation %q", req.Operation)}
+	}
+	if req.Type != rt.typ {
+		return http.StatusBadRequest, rpcResponse{Error: fmt.Sprintf("operation %s must be a %s", req.Operation, rt.typ)}
+	}
+	if s.faults.shouldFail(req.Operation) {
+		return s.faults.errorStatus, rpcResponse{Error: "injected failure"}
+	}
+
+	resp, err := rt.handler(s, r, req.Params)
+	if err == nil {
+		return http.StatusOK, resp
+	}
+	var apiErr *api.APIError
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest, rpcResponse{Error: err.Error()}
+	case errors.As(err, &apiErr) && apiErr.StatusCode != 0:
+		return apiErr.StatusCode, rpcResponse{Error: apiErr.Message}
+	default:
+		return http.StatusInternalServerError, rpcResponse{Error: err.Error()}
+	}
+}
+
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: invalid params: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+func handleProductList(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.ProductListParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	products, count, err := s.backend.ListProducts(r.Context(), params)
+	return rpcResponse{Data: products, Count: count}, err
+}
+
+func handleProductGet(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.ProductGetParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	product, err := s.backend.GetProduct(r.Context(), params.ID)
+	return rpcResponse{Data: product}, err
+}
+
+func handleProductSearch(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.ProductSearchParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	products, count, err := s.backend.SearchProducts(r.Context(), params)
+	return rpcResponse{Data: products, Count: count}, err
+}
+
+func handleProductCreate(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.ProductCreateParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	product, err := s.backend.CreateProduct(r.Context(), params)
+	return rpcResponse{Data: product}, err
+}
+
+func handleProductUpdate(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.ProductUpdateParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	product, err := s.backend.UpdateProduct(r.Context(), params)
+	return rpcResponse{Data: product}, err
+}
+
+func handleProductDelete(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.ProductDeleteParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	err := s.backend.DeleteProduct(r.Context(), params.ID)
+	return rpcResponse{Data: map[string]string{"id": params.ID}}, err
+}
+
+func handleCategoryList(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.CategoryListParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	categories, count, err := s.backend.ListCategories(r.Context(), params)
+	return rpcResponse{Data: categories, Count: count}, err
+}
+
+func handleCategoryGet(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.CategoryGetParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	category, err := s.backend.GetCategory(r.Context(), params.ID)
+	return rpcResponse{Data: category}, err
+}
+
+func handleCategoryCreate(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.CategoryCreateParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	category, err := s.backend.CreateCategory(r.Context(), params)
+	return rpcResponse{Data: category}, err
+}
+
+func handleCategoryUpdate(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.CategoryUpdateParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	category, err := s.backend.UpdateCategory(r.Context(), params)
+	return rpcResponse{Data: category}, err
+}
+
+func handleCategoryDelete(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.CategoryDeleteParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	err := s.backend.DeleteCategory(r.Context(), params.ID)
+	return rpcResponse{Data: map[string]string{"id": params.ID}}, err
+}
+
+func handleOrderCreate(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.OrderCreateParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.IdempotencyKey == "" {
+		params.IdempotencyKey = r.Header.Get(api.IdempotencyHeader)
+	}
+	order, err := s.backend.CreateOrder(r.Context(), params)
+	return rpcResponse{Data: order}, err
+}
+
+func handleOrderList(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.OrderListParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	orders, count, err := s.backend.ListOrders(r.Context(), params)
+	return rpcResponse{Data: orders, Count: count}, err
+}
+
+func handleOrderGet(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.OrderGetParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	order, err := s.backend.GetOrder(r.Context(), params.ID)
+	return rpcResponse{Data: order}, err
+}
+
+func handleOrderUpdateStatus(s *server, r *http.Request, raw json.RawMessage) (rpcResponse, error) {
+	var params types.OrderUpdateStatusParams
+	if err := decodeParams(raw, &params); err != nil {
+		return rpcResponse{}, err
+	}
+	if params.ID == "" {
+		return rpcResponse{}, fmt.Errorf("%w: id is required", errBadRequest)
+	}
+	if !params.Status.Type.Valid() {
+		return rpcResponse{}, fmt.Errorf("%w: unknown order status %q", errBadRequest, params.Status.Type)
+	}
+	order, err := s.backend.UpdateOrderStatus(r.Context(), params)
+	return rpcResponse{Data: order}, err
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp rpcResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("write response: %v", err)
+	}
+}
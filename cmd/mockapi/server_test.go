@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terminal-echoware/internal/api"
+	"terminal-echoware/pkg/types"
+)
+
+// mockClient serves the fixtures with f and returns a client talking to it.
+// The client neither retries nor trips a breaker, so each call is one request.
+func mockClient(t *testing.T, f faults) *api.Client {
+	t.Helper()
+	backend, err := api.LoadMemoryBackend("../../fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(&server{backend: backend, faults: f})
+	t.Cleanup(srv.Close)
+	client := api.NewClient(srv.URL)
+	client.Retry = api.RetryPolicy{MaxAttempts: 1}
+	client.Breaker = nil
+	return client
+}
+
+func TestServerPaging(t *testing.T) {
+	client := mockClient(t, faults{})
+	ctx := context.Background()
+	all, total, err := client.ListProducts(ctx, types.ProductListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total == 0 || len(all) != total {
+		t.Fatalf("got %d products, total %d", len(all), total)
+	}
+
+	const take = 2
+	var paged []types.Product
+	for skip := 0; skip < total; skip += take {
+		ps, n, err := client.ListProducts(ctx, types.ProductListParams{Skip: skip, Take: take})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != total {
+			t.Errorf("page at %d: count %d, want %d", skip, n, total)
+		}
+		paged = append(paged, ps...)
+	}
+	if len(paged) != total {
+		t.Fatalf("pages held %d products, want %d", len(paged), total)
+	}
+	for i := range paged {
+		if paged[i].ID != all[i].ID {
+			t.Errorf("product %d: %s, want %s", i, paged[i].ID, all[i].ID)
+		}
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	client := mockClient(t, faults{})
+	tests := []struct {
+		query string
+		want  string // ID of the first match; "" for none
+	}{
+		{"classic tee", "prd-0001"},
+		{"TEE", "prd-0001"},
+		{"no such thing", ""},
+	}
+	for _, tt := range tests {
+		ps, n, err := client.SearchProducts(context.Background(), types.ProductSearchParams{SearchTerm: tt.query, Take: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if len(ps) > 0 {
+			got = ps[0].ID
+		}
+		if got != tt.want || n < len(ps) {
+			t.Errorf("search %q: first match %q of %d, want %q", tt.query, got, n, tt.want)
+		}
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	client := mockClient(t, faults{})
+	ctx := context.Background()
+
+	p, err := client.GetProduct(ctx, "prd-0001")
+	if err != nil || p.ID != "prd-0001" {
+		t.Fatalf("GetProduct = %v, %v", p, err)
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		class  error
+		status int
+	}{
+		{
+			name:   "unknown product",
+			call:   func() error { _, err := client.GetProduct(ctx, "prd-nope"); return err },
+			class:  api.ErrNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name: "query sent as a mutation",
+			call: func() error {
+				_, err := client.CallAPI(ctx, types.APIRequest{Type: types.OperationTypeMutation, Operation: "product.list"})
+				return err
+			},
+			class:  api.ErrValidation,
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "unknown operation",
+			call: func() error {
+				_, err := client.CallAPI(ctx, types.APIRequest{Type: types.OperationTypeQuery, Operation: "product.frobnicate"})
+				return err
+			},
+			class:  api.ErrValidation,
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var apiErr *api.APIError
+			if !errors.Is(err, tt.class) || !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("err = %v, want %v with status %d", err, tt.class, tt.status)
+			}
+		})
+	}
+}
+
+func TestServerInjectsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		faults      faults
+		listFails   bool
+		searchFails bool
+	}{
+		{"every operation", faults{errorRate: 1, errorStatus: http.StatusServiceUnavailable}, true, true},
+		{"status 500", faults{errorRate: 1, errorStatus: http.StatusInternalServerError}, true, true},
+		{"listed operations only", faults{errorRate: 1, errorStatus: http.StatusServiceUnavailable, errorOps: splitOps("product.search")}, false, true},
+		{"off", faults{errorStatus: http.StatusServiceUnavailable}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockClient(t, tt.faults)
+			ctx := context.Background()
+			_, _, err := client.ListProducts(ctx, types.ProductListParams{Take: 2})
+			if got := errors.Is(err, api.ErrUnavailable); got != tt.listFails || (err != nil && !got) {
+				t.Errorf("list: err = %v, want failure %v", err, tt.listFails)
+			}
+			_, _, err = client.SearchProducts(ctx, types.ProductSearchParams{SearchTerm: "tee"})
+			if got := errors.Is(err, api.ErrUnavailable); got != tt.searchFails || (err != nil && !got) {
+				t.Errorf("search: err = %v, want failure %v", err, tt.searchFails)
+			}
+		})
+	}
+}
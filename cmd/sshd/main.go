@@ -2,11 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"maps"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
+	"terminal-echoware/internal/account"
 	"terminal-echoware/internal/api"
+	"terminal-echoware/internal/audit"
 	"terminal-echoware/internal/tui"
 	"terminal-echoware/pkg/config"
 	"time"
@@ -15,65 +23,235 @@ import (
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/bubbletea"
 	"github.com/charmbracelet/wish/logging"
+	gossh "golang.org/x/crypto/ssh"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	cfg := config.GetConfig()
+	configPath := flag.String("config", os.Getenv("NRIX_CONFIG"), "path to a YAML, TOML or JSON config file")
+	checkConfig := flag.Bool("check-config", false, "validate the configuration and exit")
+	flag.Parse()
 
-	apiBaseURL := os.Getenv("API_BASE_URL")
-	if apiBaseURL == "" {
-		apiBaseURL = cfg.APIBaseURL
-	} else {
-		cfg.APIBaseURL = apiBaseURL
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		if *checkConfig {
+			fmt.Fprintf(os.Stderr, "config is invalid:\n%v\n", err)
+			os.Exit(1)
+		}
+		log.Fatalf("config: %v", err)
+	}
+	if *checkConfig {
+		fmt.Println("config ok")
+		return
 	}
 
-	sshPort := os.Getenv("SSH_PORT")
-	if sshPort != "" {
-		cfg.SSHPort = sshPort
+	auditLog, err := openAuditLog(cfg.Audit)
+	if err != nil {
+		log.Fatalf("audit log: %v", err)
 	}
+	defer auditLog.Close()
 
-	showControls := os.Getenv("SHOW_CONTROLS")
-	if showControls == "false" {
-		cfg.ShowControls = false
+	accounts, err := account.Open(cfg.Accounts.Path)
+	if err != nil {
+		log.Fatalf("accounts: %v", err)
 	}
+	defer func() {
+		if err := accounts.Close(); err != nil {
+			log.Printf("accounts: %v", err)
+		}
+	}()
 
-	apiClient := api.NewClient(apiBaseURL)
+	// Record/replay apply to every storefront's client.
+	if os.Getenv("API_RECORD") != "" && os.Getenv("API_REPLAY") != "" {
+		log.Fatal("API_RECORD and API_REPLAY are mutually exclusive; set one of them")
+	}
+	var transport http.RoundTripper
+	if cassette := os.Getenv("API_RECORD"); cassette != "" {
+		recorder, err := api.NewRecordingTransport(nil, cassette)
+		if err != nil {
+			log.Fatalf("record: %v", err)
+		}
+		defer recorder.Close()
+		transport = recorder
+		log.Printf("Recording API traffic to %s", cassette)
+	}
+	if cassette := os.Getenv("API_REPLAY"); cassette != "" {
+		replayer, err := api.LoadReplayTransport(cassette)
+		if err != nil {
+			log.Fatalf("replay: %v", err)
+		}
+		transport = replayer
+		log.Printf("Replaying API traffic from %s", cassette)
+	}
 
-	s, err := wish.NewServer(
-		wish.WithAddress(":"+cfg.SSHPort),
-		wish.WithHostKeyPath(".ssh/term_info_ed25519"),
-		wish.WithMiddleware(
-			bubbletea.Middleware(func(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
-				return tui.NewModel(apiClient), []tea.ProgramOption{
-					tea.WithAltScreen(),
-					tea.WithMouseCellMotion(),
-				}
-			}),
-			logging.Middleware(),
-		),
-	)
-	if err != nil {
-		log.Fatal(err)
+	var fixtures *api.MemoryBackend
+	if fixturesDir := os.Getenv("API_FIXTURES"); fixturesDir != "" {
+		fixtures, err = api.LoadMemoryBackend(fixturesDir)
+		if err != nil {
+			log.Fatalf("load fixtures: %v", err)
+		}
+		log.Printf("Serving catalog from fixtures in %s", fixturesDir)
 	}
 
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	// Each storefront gets its own client and catalog cache, shared by all
+	// of its sessions. "" is the top-level shop.
+	shops := make(map[string]*shop)
+	for _, name := range append([]string{""}, slices.Sorted(maps.Keys(cfg.Storefronts))...) {
+		shops[name] = newShop(cfg.Storefront(name).APIBaseURL, auditLog, transport, fixtures)
+		if name != "" {
+			log.Printf("Storefront %s -> %s", name, cfg.Storefront(name).APIBaseURL)
+		}
+	}
 
-	log.Printf("SSH server starting on :%s", cfg.SSHPort)
+	store := config.NewStore(*configPath, cfg)
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	reload := func() { reloadConfig(store, shops) }
 
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
 	go func() {
-		if err := s.ListenAndServe(); err != nil {
-			log.Fatal(err)
+		for range hup {
+			reload()
 		}
 	}()
+	if cfg.Reload.Watch && *configPath != "" {
+		interval, _ := cfg.Reload.PollInterval()
+		go store.Watch(watchCtx, interval, reload)
+		log.Printf("Watching %s for changes every %s", *configPath, interval)
+	}
+
+	var servers []*ssh.Server
+	for _, port := range cfg.Ports() {
+		s, err := wish.NewServer(
+			wish.WithAddress(":"+port),
+			wish.WithHostKeyPath(".ssh/term_info_ed25519"),
+			// Any key is welcome: it identifies the customer, it does not
+			// grant anything beyond a shopping session.
+			wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+			wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool {
+				return store.Current().Accounts.AllowGuests
+			}),
+			wish.WithMiddleware(
+				bubbletea.Middleware(func(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
+					ctx := audit.WithSessionID(sess.Context(), sess.Context().SessionID())
+					overlay := config.OverlayFromEnv(sess.Environ())
+					overlay.Storefront = store.Current().Route(sess.User(), port)
+					sh, ok := shops[overlay.Storefront]
+					if !ok {
+						// Added by a reload; it gets a backend on restart.
+						overlay.Storefront, sh = "", shops[""]
+					}
+					return tui.NewModel(ctx, sh.backend, store, overlay, accounts, identify(sess, accounts)), []tea.ProgramOption{
+						tea.WithAltScreen(),
+						tea.WithMouseCellMotion(),
+					}
+				}),
+				logging.Middleware(),
+			),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		servers = append(servers, s)
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+
+	for _, s := range servers {
+		log.Printf("SSH server starting on %s", s.Addr)
+		go func() {
+			if err := s.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+				log.Fatal(err)
+			}
+		}()
+	}
 
 	<-done
 	log.Println("Shutting down...")
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	if err := s.Shutdown(ctx); err != nil {
-		log.Fatal(err)
+	for _, s := range servers {
+		if err := s.Shutdown(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// shop is the backend side of one storefront.
+type shop struct {
+	client  *api.Client
+	cache   *api.CachedBackend
+	backend api.Backend
+}
+
+func newShop(baseURL string, auditLog *audit.Logger, transport http.RoundTripper, fixtures *api.MemoryBackend) *shop {
+	client := api.NewClient(baseURL)
+	client.Audit = auditLog
+	if transport != nil {
+		client.HTTPClient.Transport = transport
+	}
+	sh := &shop{client: client, cache: api.NewCachedBackend(client, api.DefaultCacheConfig)}
+	sh.backend = sh.cache
+	if fixtures != nil {
+		sh.backend = fixtures
+	}
+	return sh
+}
+
+// identify maps the session's public key to a customer. Keys the shop has
+// not seen get their account once they save something; sessions without a
+// key are guests.
+func identify(sess ssh.Session, accounts *account.Store) account.Identity {
+	id := account.Identity{User: sess.User()}
+	key := sess.PublicKey()
+	if key == nil {
+		return id
+	}
+	id.Fingerprint = gossh.FingerprintSHA256(key)
+	if c, ok := accounts.Connect(id.Fingerprint); ok {
+		id.CustomerID = c.ID
+	}
+	return id
+}
+
+// reloadConfig re-reads the config and applies what can change while the
+// server runs. Sessions pick up the rest themselves; see config.ReloadConfig.
+func reloadConfig(store *config.Store, shops map[string]*shop) {
+	prev, next, err := store.Reload()
+	if err != nil {
+		log.Printf("config reload rejected, keeping current config: %v", err)
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(shops)) {
+		url := next.Storefront(name).APIBaseURL
+		if url != prev.Storefront(name).APIBaseURL {
+			shops[name].client.SetBaseURL(url)
+			shops[name].cache.Invalidate()
+			log.Printf("API base URL for %q is now %s", name, url)
+		}
+	}
+	if next.SSHPort != prev.SSHPort || next.Audit != prev.Audit || next.Accounts.Path != prev.Accounts.Path ||
+		next.Reload.Watch != prev.Reload.Watch || next.Reload.Interval != prev.Reload.Interval ||
+		!slices.Equal(next.Ports(), prev.Ports()) || !slices.Equal(slices.Sorted(maps.Keys(next.Storefronts)), slices.Sorted(maps.Keys(prev.Storefronts))) {
+		log.Printf("config reloaded; port, storefront list, audit, accounts.path and reload.watch/interval changes need a restart")
+		return
+	}
+	log.Printf("config reloaded")
+}
+
+func openAuditLog(cfg config.AuditConfig) (*audit.Logger, error) {
+	mode, err := cfg.Mode()
+	if err != nil {
+		return nil, err
 	}
+	return audit.Open(audit.Config{
+		Path:       cfg.Path,
+		MaxSize:    int64(cfg.MaxSizeMB) << 20,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+		Mode:       mode,
+	})
 }
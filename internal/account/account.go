@@ -0,0 +1,353 @@
+// Package account keeps customer records keyed by the fingerprint of the SSH
+// public key a customer signs in with. A record is created the first time
+// something is saved for a key, not when it merely connects, and holds what
+// the shop remembers between sessions: saved addresses, order history and
+// the cart.
+package account
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"terminal-echoware/pkg/types"
+)
+
+// MaxAddresses bounds the saved addresses per customer; the least recently
+// used one is dropped first.
+const MaxAddresses = 5
+
+// Customer is one account. Addresses and Orders are most recent first.
+type Customer struct {
+	ID          string                  `json:"id"`
+	Fingerprint string                  `json:"fingerprint"`
+	Name        string                  `json:"name"` // SSH username when the record was created
+	CreatedAt   time.Time               `json:"created_at"`
+	LastSeen    time.Time               `json:"last_seen"`
+	Addresses   []types.ShippingDetails `json:"addresses,omitempty"`
+	Orders      []OrderRef              `json:"orders,omitempty"`
+
+	// Carts holds the unfinished cart for each storefront the customer
+	// shopped at, keyed by storefront name ("" is the default shop).
+	Carts map[string][]types.CartItem `json:"carts,omitempty"`
+}
+
+// OrderRef is what the shop remembers about an order it placed.
+type OrderRef struct {
+	ID       string                `json:"id"`
+	Shop     string                `json:"shop"` // storefront name, as for Carts
+	PlacedAt time.Time             `json:"placed_at"`
+	Total    types.Money           `json:"total"`
+	Items    int                   `json:"items"`
+	Status   types.OrderStatusType `json:"status"`
+}
+
+// Identity is who a session belongs to. Guests have no fingerprint and no
+// customer record; keys that have not saved anything yet have no CustomerID.
+type Identity struct {
+	User        string // SSH username
+	Fingerprint string // SHA256 public key fingerprint
+	CustomerID  string
+}
+
+// Guest reports whether the session signed in without a key.
+func (i Identity) Guest() bool {
+	return i.Fingerprint == ""
+}
+
+// SaveDelay is how long the store collects changes before writing them out,
+// so a burst of cart edits costs one write.
+const SaveDelay = time.Second
+
+// Store holds every customer and writes them to a JSON file in the
+// background, at most once per SaveDelay; changes only update memory, so
+// callers never wait on the disk. Close writes what is pending. A nil *Store
+// remembers nothing, so callers need not check whether accounts are enabled.
+type Store struct {
+	mu        sync.Mutex
+	path      string // empty keeps customers in memory only
+	customers map[string]*Customer
+	dirty     bool // changed since the last write
+
+	writeMu sync.Mutex // serialises writes of the file
+	changed chan struct{}
+	closing chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// Open loads the customers in path, which need not exist yet, and starts
+// writing changes back to it.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, customers: make(map[string]*Customer)}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read accounts: %w", err)
+	}
+	if err == nil {
+		var customers []*Customer
+		if err := json.Unmarshal(data, &customers); err != nil {
+			return nil, fmt.Errorf("parse accounts %s: %w", path, err)
+		}
+		for _, c := range customers {
+			s.customers[c.Fingerprint] = c
+		}
+	}
+	s.changed = make(chan struct{}, 1)
+	s.closing = make(chan struct{})
+	s.closed = make(chan struct{})
+	go s.saveLoop()
+	return s, nil
+}
+
+// Close stops the background writer and writes any pending changes.
+func (s *Store) Close() error {
+	if s == nil || s.path == "" {
+		return nil
+	}
+	s.once.Do(func() {
+		close(s.closing)
+		<-s.closed
+	})
+	return s.Flush()
+}
+
+// Flush writes pending changes now.
+func (s *Store) Flush() error {
+	if s == nil || s.path == "" {
+		return nil
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	customers := make([]Customer, 0, len(s.customers))
+	for _, c := range s.customers {
+		customers = append(customers, c.clone())
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	if err := s.write(customers); err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *Store) saveLoop() {
+	defer close(s.closed)
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-s.changed:
+		}
+		select {
+		case <-s.closing:
+			return
+		case <-time.After(SaveDelay):
+		}
+		if err := s.Flush(); err != nil {
+			log.Printf("accounts: %v", err)
+		}
+	}
+}
+
+// Connect returns the customer for fingerprint and notes the visit. Keys
+// without a record get none: public-key auth accepts any key, so records are
+// only created once a customer saves something (see update). The visit is
+// written out with the customer's next change rather than on every connect.
+func (s *Store) Connect(fingerprint string) (Customer, bool) {
+	if s == nil || fingerprint == "" {
+		return Customer{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.customers[fingerprint]
+	if !ok {
+		return Customer{}, false
+	}
+	c.LastSeen = time.Now().UTC()
+	return c.clone(), true
+}
+
+// Customer returns a copy of the customer for fingerprint.
+func (s *Store) Customer(fingerprint string) (Customer, bool) {
+	if s == nil || fingerprint == "" {
+		return Customer{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.customers[fingerprint]
+	if !ok {
+		return Customer{}, false
+	}
+	return c.clone(), true
+}
+
+// SaveAddress remembers addr for the customer, moving it to the front if an
+// equal address is already saved.
+func (s *Store) SaveAddress(id Identity, addr types.ShippingDetails) {
+	s.update(id, true, func(c *Customer) {
+		c.Addresses = slices.DeleteFunc(c.Addresses, func(a types.ShippingDetails) bool {
+			return sameAddress(a, addr)
+		})
+		c.Addresses = append([]types.ShippingDetails{addr}, c.Addresses...)
+		if len(c.Addresses) > MaxAddresses {
+			c.Addresses = c.Addresses[:MaxAddresses]
+		}
+	})
+}
+
+// RecordOrder adds order, placed at storefront shop, to the customer's
+// history.
+func (s *Store) RecordOrder(id Identity, shop string, order types.Order) {
+	items := 0
+	for _, item := range order.OrderItems {
+		items += item.Quantity
+	}
+	ref := OrderRef{
+		ID:       order.ID,
+		Shop:     shop,
+		PlacedAt: time.Now().UTC(),
+		Total:    order.TotalAmount,
+		Items:    items,
+		Status:   order.Status.Type,
+	}
+	s.update(id, true, func(c *Customer) {
+		c.Orders = append([]OrderRef{ref}, c.Orders...)
+	})
+}
+
+// Cart returns the customer's saved cart at storefront.
+func (s *Store) Cart(fingerprint, storefront string) []types.CartItem {
+	c, _ := s.Customer(fingerprint)
+	return c.Carts[storefront]
+}
+
+// SaveCart replaces the customer's cart at storefront with items. An empty
+// cart is forgotten. Sessions sharing a key each save their whole cart, so
+// the last change wins.
+func (s *Store) SaveCart(id Identity, storefront string, items []types.CartItem) {
+	items = cloneItems(items)
+	// Emptying the cart of a key with no record leaves nothing to remember.
+	s.update(id, len(items) > 0, func(c *Customer) {
+		if len(items) == 0 {
+			delete(c.Carts, storefront)
+			return
+		}
+		if c.Carts == nil {
+			c.Carts = make(map[string][]types.CartItem)
+		}
+		c.Carts[storefront] = items
+	})
+}
+
+// update applies fn to id's customer and schedules a write. A key without a
+// record gets one first if create is set; otherwise there is nothing to
+// change.
+func (s *Store) update(id Identity, create bool, fn func(*Customer)) {
+	if s == nil || id.Guest() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.customers[id.Fingerprint]
+	if !ok {
+		if !create {
+			return
+		}
+		now := time.Now().UTC()
+		c = &Customer{ID: newID(), Fingerprint: id.Fingerprint, Name: id.User, CreatedAt: now, LastSeen: now}
+		s.customers[id.Fingerprint] = c
+	}
+	fn(c)
+	if s.path == "" {
+		return
+	}
+	s.dirty = true
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// write writes customers to a temporary file and renames it over the old
+// one, so a crash never leaves a half-written file. Callers hold s.writeMu.
+func (s *Store) write(customers []Customer) error {
+	slices.SortFunc(customers, func(a, b Customer) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	data, err := json.MarshalIndent(customers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode accounts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create accounts dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write accounts: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write accounts: %w", err)
+	}
+	return nil
+}
+
+func (c *Customer) clone() Customer {
+	out := *c
+	out.Addresses = slices.Clone(c.Addresses)
+	out.Orders = slices.Clone(c.Orders)
+	out.Carts = nil
+	if c.Carts != nil {
+		out.Carts = make(map[string][]types.CartItem, len(c.Carts))
+		for shop, items := range c.Carts {
+			out.Carts[shop] = cloneItems(items)
+		}
+	}
+	return out
+}
+
+// cloneItems copies items deeply enough that the caller's variant maps are
+// not shared with the store.
+func cloneItems(items []types.CartItem) []types.CartItem {
+	out := slices.Clone(items)
+	for i := range out {
+		out[i].Variant = maps.Clone(out[i].Variant)
+	}
+	return out
+}
+
+// sameAddress compares the fields a customer types in.
+func sameAddress(a, b types.ShippingDetails) bool {
+	return a.FullName == b.FullName && a.Phone == b.Phone && a.Email == b.Email &&
+		a.AddressLine1 == b.AddressLine1 && a.AddressLine2 == b.AddressLine2 &&
+		a.City == b.City && a.State == b.State && a.PostalCode == b.PostalCode &&
+		a.Country == b.Country
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "cus_" + hex.EncodeToString(b)
+}
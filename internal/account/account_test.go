@@ -0,0 +1,230 @@
+package account
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"terminal-echoware/pkg/types"
+)
+
+var asha = Identity{User: "asha", Fingerprint: "SHA256:asha"}
+
+func address(line string) types.ShippingDetails {
+	return types.ShippingDetails{FullName: "Asha", AddressLine1: line, City: "Pune"}
+}
+
+func TestConnectCreatesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		if _, ok := s.Connect(fmt.Sprintf("SHA256:scanner-%d", i)); ok {
+			t.Fatal("Connect found a record for a new key")
+		}
+	}
+	s.SaveCart(asha, "", nil)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.customers) != 0 {
+		t.Errorf("%d records created without anything saved", len(s.customers))
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("accounts file written without anything saved")
+	}
+}
+
+func TestFirstSaveCreatesRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		save func(*Store)
+	}{
+		{"address", func(s *Store) { s.SaveAddress(asha, address("Home")) }},
+		{"cart", func(s *Store) {
+			s.SaveCart(asha, "", []types.CartItem{{Product: types.Product{ID: "p1"}, Quantity: 1}})
+		}},
+		{"order", func(s *Store) { s.RecordOrder(asha, "", types.Order{ID: "o1"}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := Open("")
+			tt.save(s)
+			c, ok := s.Connect(asha.Fingerprint)
+			if !ok || c.ID == "" || c.Name != "asha" || c.CreatedAt.IsZero() {
+				t.Fatalf("record = %+v, %v", c, ok)
+			}
+			if again, _ := s.Connect(asha.Fingerprint); again.ID != c.ID || again.LastSeen.Before(c.LastSeen) {
+				t.Errorf("second connect: %+v", again)
+			}
+		})
+	}
+}
+
+func TestSaveAddress(t *testing.T) {
+	tests := []struct {
+		name  string
+		saves []string
+		want  []string // most recent first
+	}{
+		{"one", []string{"Home"}, []string{"Home"}},
+		{"most recent first", []string{"Home", "Work"}, []string{"Work", "Home"}},
+		{"reuse moves to front", []string{"Home", "Work", "Home"}, []string{"Home", "Work"}},
+		{"capped", []string{"1", "2", "3", "4", "5", "6", "7"}, []string{"7", "6", "5", "4", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := Open("")
+			for _, line := range tt.saves {
+				s.SaveAddress(asha, address(line))
+			}
+			c, _ := s.Customer(asha.Fingerprint)
+			var got []string
+			for _, a := range c.Addresses {
+				got = append(got, a.AddressLine1)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("addresses = %v, want %v", got, tt.want)
+			}
+			if len(c.Addresses) > MaxAddresses {
+				t.Errorf("%d addresses kept, cap is %d", len(c.Addresses), MaxAddresses)
+			}
+		})
+	}
+}
+
+func TestCarts(t *testing.T) {
+	s, _ := Open("")
+	items := []types.CartItem{{Product: types.Product{ID: "p1"}, Quantity: 2, Variant: map[string]string{"Size": "M"}}}
+	s.SaveCart(asha, "outlet", items)
+	items[0].Variant["Size"] = "L"
+	got := s.Cart(asha.Fingerprint, "outlet")
+	if len(got) != 1 || got[0].Variant["Size"] != "M" {
+		t.Fatalf("cart = %+v; the caller's changes leaked into the store", got)
+	}
+	if other := s.Cart(asha.Fingerprint, ""); len(other) != 0 {
+		t.Errorf("cart leaked to the default storefront: %+v", other)
+	}
+	s.SaveCart(asha, "outlet", nil)
+	if c, _ := s.Customer(asha.Fingerprint); len(c.Carts) != 0 {
+		t.Errorf("empty cart kept: %+v", c.Carts)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "accounts.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SaveAddress(asha, address("Home"))
+	s.RecordOrder(asha, "outlet", types.Order{ID: "o1", TotalAmount: types.NewMoney(79900, ""),
+		OrderItems: []types.OrderItem{{Quantity: 2}}})
+	s.SaveCart(asha, "", []types.CartItem{{Product: types.Product{ID: "p1"}, Quantity: 1}})
+	want, _ := s.Customer(asha.Fingerprint)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("accounts file mode = %o, want 600", mode)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := reopened.Customer(asha.Fingerprint)
+	if !ok {
+		t.Fatal("customer lost on reopen")
+	}
+	if got.ID != want.ID || len(got.Addresses) != 1 || len(got.Carts[""]) != 1 ||
+		len(got.Orders) != 1 || got.Orders[0].Items != 2 || got.Orders[0].Total != want.Orders[0].Total {
+		t.Errorf("reopened = %+v, want %+v", got, want)
+	}
+}
+
+func TestOpenErrors(t *testing.T) {
+	bad := filepath.Join(t.TempDir(), "accounts.json")
+	os.WriteFile(bad, []byte("{"), 0o600)
+	if _, err := Open(bad); err == nil {
+		t.Error("Open of a corrupt file succeeded")
+	}
+	if s, err := Open(filepath.Join(t.TempDir(), "none.json")); err != nil || s == nil {
+		t.Errorf("Open of a missing file = %v, %v", s, err)
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	if _, ok := s.Connect(asha.Fingerprint); ok {
+		t.Error("nil store found a customer")
+	}
+	s.SaveAddress(asha, address("Home"))
+	s.RecordOrder(asha, "", types.Order{})
+	s.SaveCart(asha, "", []types.CartItem{{Quantity: 1}})
+	if err := s.Flush(); err != nil {
+		t.Error(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Error(err)
+	}
+	if _, ok := s.Customer(asha.Fingerprint); ok || s.Cart(asha.Fingerprint, "") != nil {
+		t.Error("nil store remembered something")
+	}
+}
+
+func TestGuestsAreNotSaved(t *testing.T) {
+	s, _ := Open("")
+	guest := Identity{User: "guest"}
+	s.SaveAddress(guest, address("Home"))
+	s.RecordOrder(guest, "", types.Order{ID: "o1"})
+	if len(s.customers) != 0 {
+		t.Errorf("guest got a record")
+	}
+}
+
+func TestSavesInBackground(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// A burst of cart edits returns without touching the disk...
+	for n := 1; n <= types.MaxCartQuantity; n++ {
+		s.SaveCart(asha, "", []types.CartItem{{Product: types.Product{ID: "p1"}, Quantity: n}})
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("SaveCart wrote the file on the caller's goroutine")
+	}
+
+	// ...and is written once, after SaveDelay, with the last change.
+	deadline := time.Now().Add(5 * SaveDelay)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("changes never written")
+		}
+		time.Sleep(SaveDelay / 10)
+	}
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if cart := reopened.Cart(asha.Fingerprint, ""); len(cart) != 1 || cart[0].Quantity != types.MaxCartQuantity {
+		t.Errorf("written cart = %+v", cart)
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"terminal-echoware/pkg/types"
+)
+
+// Backend is the set of shop operations the TUI depends on. *Client talks to
+// the live API; MemoryBackend serves a local catalog for offline use.
+type Backend interface {
+	ListProducts(ctx context.Context, params types.ProductListParams) ([]types.Product, int, error)
+	GetProduct(ctx context.Context, id string) (*types.Product, error)
+	SearchProducts(ctx context.Context, params types.ProductSearchParams) ([]types.Product, int, error)
+	ListCategories(ctx context.Context, params types.CategoryListParams) ([]types.Category, int, error)
+	CreateOrder(ctx context.Context, params types.OrderCreateParams) (*types.Order, error)
+}
+
+// HealthReporter is implemented by backends that can tell when the upstream
+// is unreachable and only cached data can be served.
+type HealthReporter interface {
+	Degraded() bool
+}
+
+// IsDegraded reports whether b is serving in degraded, read-only mode.
+func IsDegraded(b Backend) bool {
+	h, ok := b.(HealthReporter)
+	return ok && h.Degraded()
+}
+
+// Uncacher is implemented by caching backends that can hand out the backend
+// they wrap.
+type Uncacher interface {
+	Uncached() Backend
+}
+
+// Uncached returns b without any cache in front of it.
+func Uncached(b Backend) Backend {
+	for {
+		u, ok := b.(Uncacher)
+		if !ok {
+			return b
+		}
+		b = u.Uncached()
+	}
+}
+
+var (
+	_ Backend = (*Client)(nil)
+	_ Backend = (*MemoryBackend)(nil)
+	_ Backend = (*CachedBackend)(nil)
+)
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the backend while the
+// breaker is open. It is reported as ErrUnavailable.
+var ErrCircuitOpen = errors.New("circuit open: backend marked unavailable")
+
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive unavailable-class
+	// failures that trips the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting a
+	// single probe request through.
+	OpenTimeout time.Duration
+}
+
+var DefaultBreakerConfig = BreakerConfig{
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// Breaker is a consecutive-failure circuit breaker. Only failures that mean
+// the backend is unreachable count; not-found and validation errors are
+// normal answers. A nil *Breaker allows everything.
+type Breaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	return &Breaker{cfg: cfg}
+}
+
+// Reset closes the breaker and forgets past failures, e.g. after the client
+// is pointed at a different backend.
+func (b *Breaker) Reset() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Allow reports whether a request may be sent now. After OpenTimeout one
+// caller is let through as a probe; everyone else keeps failing fast until
+// the probe's result is recorded.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Record feeds the outcome of an allowed request back into the breaker.
+func (b *Breaker) Record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.probing
+	b.probing = false
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		// The caller gave up; says nothing about the backend.
+	case err == nil || !errors.Is(err, ErrUnavailable):
+		b.state = breakerClosed
+		b.failures = 0
+	default:
+		b.failures++
+		if wasProbe || b.failures >= b.cfg.FailureThreshold {
+			b.state = breakerOpen
+			b.openedAt = time.Now()
+		}
+	}
+}
+
+// Open reports whether requests are being refused: the breaker is open and
+// OpenTimeout has not passed yet, or a probe is in flight. Once a probe may
+// be sent the breaker no longer reports open, so callers that check it
+// before a request (checkout, say) send that probe rather than waiting for
+// some other call to.
+func (b *Breaker) Open() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		return time.Since(b.openedAt) < b.cfg.OpenTimeout
+	case breakerHalfOpen:
+		return b.probing
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terminal-echoware/pkg/types"
+)
+
+func TestBreakerTrips(t *testing.T) {
+	unavailable := fmt.Errorf("list: %w", ErrUnavailable)
+	tests := []struct {
+		name     string
+		failures []error
+		wantOpen bool
+	}{
+		{"below threshold", []error{unavailable, unavailable}, false},
+		{"at threshold", []error{unavailable, unavailable, unavailable}, true},
+		{"success resets", []error{unavailable, unavailable, nil, unavailable}, false},
+		{"not found resets", []error{unavailable, unavailable, ErrNotFound, unavailable}, false},
+		{"validation is an answer", []error{ErrValidation, ErrValidation, ErrValidation}, false},
+		{"cancel is ignored", []error{unavailable, context.Canceled, unavailable, unavailable}, true},
+		{"cancel alone", []error{context.Canceled, context.Canceled, context.Canceled}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Hour})
+			for _, err := range tt.failures {
+				if err := b.Allow(); err != nil {
+					t.Fatalf("Allow = %v before the breaker tripped", err)
+				}
+				b.Record(err)
+			}
+			if b.Open() != tt.wantOpen {
+				t.Fatalf("Open = %v, want %v", b.Open(), tt.wantOpen)
+			}
+			if err := b.Allow(); tt.wantOpen != errors.Is(err, ErrCircuitOpen) {
+				t.Errorf("Allow = %v", err)
+			}
+		})
+	}
+}
+
+// tripped returns a breaker that opened OpenTimeout ago and is ready to let
+// a probe through.
+func tripped() *Breaker {
+	b := NewBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour})
+	b.Allow()
+	b.Record(ErrUnavailable)
+	b.openedAt = time.Now().Add(-b.cfg.OpenTimeout)
+	return b
+}
+
+func TestBreakerProbe(t *testing.T) {
+	tests := []struct {
+		name     string
+		probe    error
+		wantOpen bool
+	}{
+		{"probe succeeds", nil, false},
+		{"probe gets an answer", ErrNotFound, false},
+		{"probe fails", ErrUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tripped()
+			if b.Open() {
+				t.Fatal("Open after OpenTimeout, before the probe")
+			}
+			if err := b.Allow(); err != nil {
+				t.Fatalf("probe not allowed: %v", err)
+			}
+			if !b.Open() {
+				t.Fatal("not Open while the probe is in flight")
+			}
+			if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("second caller during the probe: Allow = %v", err)
+			}
+			b.Record(tt.probe)
+			if b.Open() != tt.wantOpen {
+				t.Errorf("Open = %v, want %v", b.Open(), tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestBreakerCancelledProbe(t *testing.T) {
+	b := tripped()
+	b.Allow()
+	b.Record(context.Canceled)
+	if b.Open() {
+		t.Fatal("Open after a cancelled probe, although a new probe may go")
+	}
+	if err := b.Allow(); err != nil {
+		t.Errorf("no new probe after a cancelled one: %v", err)
+	}
+}
+
+func TestBreakerStaysOpenUntilTimeout(t *testing.T) {
+	b := NewBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour})
+	b.Allow()
+	b.Record(ErrUnavailable)
+	for range 3 {
+		if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) || !b.Open() {
+			t.Fatalf("Allow = %v, Open = %v before OpenTimeout", err, b.Open())
+		}
+	}
+}
+
+func TestBreakerReset(t *testing.T) {
+	b := tripped()
+	b.Reset()
+	if b.Open() {
+		t.Fatal("Reset left the breaker open")
+	}
+	if err := b.Allow(); err != nil {
+		t.Errorf("Allow = %v after Reset", err)
+	}
+}
+
+func TestNilBreaker(t *testing.T) {
+	var b *Breaker
+	b.Record(ErrUnavailable)
+	b.Reset()
+	if err := b.Allow(); err != nil || b.Open() {
+		t.Errorf("nil breaker: Allow = %v, Open = %v", err, b.Open())
+	}
+}
+
+func TestBreakerRecoversBehindCache(t *testing.T) {
+	var down atomic.Bool
+	var orders atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":"down"}`)
+			return
+		}
+		var req types.APIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.Operation {
+		case "order.create":
+			orders.Add(1)
+			fmt.Fprint(w, `{"data":{"_id":"o1"}}`)
+		default:
+			fmt.Fprint(w, `{"data":[{"_id":"p1","name":"Tee"}],"count":1}`)
+		}
+	}))
+	defer srv.Close()
+
+	const openTimeout = 50 * time.Millisecond
+	client := NewClient(srv.URL)
+	client.Retry = RetryPolicy{MaxAttempts: 1}
+	client.Breaker = NewBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: openTimeout})
+	cache := NewCachedBackend(client, DefaultCacheConfig)
+	ctx := context.Background()
+
+	if _, _, err := cache.ListProducts(ctx, types.ProductListParams{Take: 10}); err != nil {
+		t.Fatal(err)
+	}
+	down.Store(true)
+	if _, err := cache.GetProduct(ctx, "p1"); err != nil {
+		t.Fatalf("stale product not served while down: %v", err)
+	}
+	if !IsDegraded(cache) {
+		t.Fatal("breaker did not trip")
+	}
+
+	// The backend comes back, but every read is a fresh cache hit, so no
+	// request would probe it.
+	down.Store(false)
+	if _, _, err := cache.ListProducts(ctx, types.ProductListParams{Take: 10}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(openTimeout)
+	if IsDegraded(cache) {
+		t.Fatal("still degraded after OpenTimeout; checkout would never probe")
+	}
+
+	params := types.OrderCreateParams{IdempotencyKey: "k1"}
+	if _, err := cache.CreateOrder(ctx, params); err != nil {
+		t.Fatalf("order as probe: %v", err)
+	}
+	if orders.Load() != 1 || IsDegraded(cache) || client.Breaker.Open() {
+		t.Errorf("orders = %d, degraded = %v after a successful probe", orders.Load(), IsDegraded(cache))
+	}
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"terminal-echoware/pkg/types"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheConfig sets how long each query result stays fresh and how many
+// results are kept overall. A zero TTL disables caching for that operation.
+type CacheConfig struct {
+	ProductListTTL   time.Duration
+	ProductGetTTL    time.Duration
+	ProductSearchTTL time.Duration
+	CategoryListTTL  time.Duration
+	MaxEntries       int
+}
+
+var DefaultCacheConfig = CacheConfig{
+	ProductListTTL:   time.Minute,
+	ProductGetTTL:    2 * time.Minute,
+	ProductSearchTTL: 30 * time.Second,
+	CategoryListTTL:  5 * time.Minute,
+	MaxEntries:       1024,
+}
+
+// CachedBackend wraps a Backend with a catalog cache shared by every session
+// using it. Concurrent identical queries are coalesced into one backend call,
+// and the least recently used results are evicted past MaxEntries. Expired
+// results are kept until evicted so they can be served, stale, while the
+// backend is unavailable. Orders are never cached.
+type CachedBackend struct {
+	next  Backend
+	cfg   CacheConfig
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front = most recently used
+}
+
+type cacheEntry struct {
+	key     string
+	value   any
+	count   int
+	expires time.Time
+}
+
+func NewCachedBackend(next Backend, cfg CacheConfig) *CachedBackend {
+	return &CachedBackend{
+		next:    next,
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (b *CachedBackend) ListProducts(ctx context.Context, params types.ProductListParams) ([]types.Product, int, error) {
+	v, count, err := b.cached(ctx, "product.list", params, b.cfg.ProductListTTL, func(ctx context.Context) (any, int, error) {
+		return b.next.ListProducts(ctx, params)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Clone(v.([]types.Product)), count, nil
+}
+
+func (b *CachedBackend) GetProduct(ctx context.Context, id string) (*types.Product, error) {
+	v, _, err := b.cached(ctx, "product.get", types.ProductGetParams{ID: id}, b.cfg.ProductGetTTL, func(ctx context.Context) (any, int, error) {
+		p, err := b.next.GetProduct(ctx, id)
+		return p, 0, err
+	})
+	if errors.Is(err, ErrUnavailable) {
+		if p, ok := b.staleProduct(id); ok {
+			return p, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	product := *v.(*types.Product)
+	return &product, nil
+}
+
+func (b *CachedBackend) SearchProducts(ctx context.Context, params types.ProductSearchParams) ([]types.Product, int, error) {
+	v, count, err := b.cached(ctx, "product.search", params, b.cfg.ProductSearchTTL, func(ctx context.Context) (any, int, error) {
+		return b.next.SearchProducts(ctx, params)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Clone(v.([]types.Product)), count, nil
+}
+
+func (b *CachedBackend) ListCategories(ctx context.Context, params types.CategoryListParams) ([]types.Category, int, error) {
+	v, count, err := b.cached(ctx, "category.list", params, b.cfg.CategoryListTTL, func(ctx context.Context) (any, int, error) {
+		return b.next.ListCategories(ctx, params)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return slices.Clone(v.([]types.Category)), count, nil
+}
+
+func (b *CachedBackend) CreateOrder(ctx context.Context, params types.OrderCreateParams) (*types.Order, error) {
+	return b.next.CreateOrder(ctx, params)
+}
+
+// Degraded reports whether the wrapped backend is unavailable, in which case
+// reads are answered from whatever the cache still holds.
+func (b *CachedBackend) Degraded() bool {
+	return IsDegraded(b.next)
+}
+
+// Uncached returns the wrapped backend, for reads that must reflect the shop
+// as it is now rather than as it was cached.
+func (b *CachedBackend) Uncached() Backend {
+	return b.next
+}
+
+// Invalidate drops cached results for the given operations, or everything
+// when none are given.
+func (b *CachedBackend) Invalidate(operations ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(operations) == 0 {
+		clear(b.entries)
+		b.lru.Init()
+		return
+	}
+	for key, el := range b.entries {
+		for _, op := range operations {
+			if strings.HasPrefix(key, op+"|") {
+				b.lru.Remove(el)
+				delete(b.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// InvalidateProduct drops the cached product and every listing it may appear
+// in. Call it after the product has been changed in the backend.
+func (b *CachedBackend) InvalidateProduct(id string) {
+	key := cacheKey("product.get", types.ProductGetParams{ID: id})
+	b.mu.Lock()
+	if el, ok := b.entries[key]; ok {
+		b.lru.Remove(el)
+		delete(b.entries, key)
+	}
+	b.mu.Unlock()
+	b.Invalidate("product.list", "product.search")
+}
+
+// cached returns a fresh cached result for op/params or fetches it, sharing
+// one in-flight fetch between concurrent callers. The shared fetch runs
+// without the caller's cancellation so one session leaving does not fail the
+// others; the wrapped backend's own deadlines still bound it. If the fetch
+// fails because the backend is unavailable, a stale result is returned
+// instead when there is one.
+func (b *CachedBackend) cached(ctx context.Context, op string, params any, ttl time.Duration, fetch func(context.Context) (any, int, error)) (any, int, error) {
+	if ttl <= 0 || b.cfg.MaxEntries <= 0 {
+		return fetch(ctx)
+	}
+	key := cacheKey(op, params)
+	e, fresh := b.lookup(key)
+	if fresh {
+		return e.value, e.count, nil
+	}
+
+	ch := b.group.DoChan(key, func() (any, error) {
+		v, count, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		e := &cacheEntry{key: key, value: v, count: count, expires: time.Now().Add(ttl)}
+		b.store(e)
+		return e, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			if e != nil && errors.Is(res.Err, ErrUnavailable) {
+				return e.value, e.count, nil
+			}
+			return nil, 0, res.Err
+		}
+		fetched := res.Val.(*cacheEntry)
+		return fetched.value, fetched.count, nil
+	}
+}
+
+// lookup returns the entry for key, if any, and whether it is still fresh.
+func (b *CachedBackend) lookup(key string) (*cacheEntry, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	el, ok := b.entries[key]
+	if !ok {
+		return nil, false
+	}
+	b.lru.MoveToFront(el)
+	e := el.Value.(*cacheEntry)
+	return e, time.Now().Before(e.expires)
+}
+
+// staleProduct looks for id in any cached listing, so product pages keep
+// working offline for everything a visitor could have browsed to.
+func (b *CachedBackend) staleProduct(id string) (*types.Product, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, el := range b.entries {
+		products, ok := el.Value.(*cacheEntry).value.([]types.Product)
+		if !ok {
+			continue
+		}
+		for _, p := range products {
+			if p.ID == id {
+				return &p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (b *CachedBackend) store(e *cacheEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if el, ok := b.entries[e.key]; ok {
+		el.Value = e
+		b.lru.MoveToFront(el)
+		return
+	}
+	b.entries[e.key] = b.lru.PushFront(e)
+	for b.lru.Len() > b.cfg.MaxEntries {
+		oldest := b.lru.Back()
+		b.lru.Remove(oldest)
+		delete(b.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func cacheKey(op string, params any) string {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return op + "|"
+	}
+	return op + "|" + string(payload)
+}
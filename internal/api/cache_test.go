@@ -0,0 +1,274 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terminal-echoware/pkg/types"
+)
+
+// countingBackend counts the calls that reach it and fails them with err
+// when set. While gate is non-nil, ListProducts waits on it.
+type countingBackend struct {
+	*MemoryBackend
+	calls atomic.Int32
+	err   error
+	gate  chan struct{}
+}
+
+func (b *countingBackend) ListProducts(ctx context.Context, params types.ProductListParams) ([]types.Product, int, error) {
+	b.calls.Add(1)
+	if b.gate != nil {
+		<-b.gate
+	}
+	if b.err != nil {
+		return nil, 0, b.err
+	}
+	return b.MemoryBackend.ListProducts(ctx, params)
+}
+
+func (b *countingBackend) GetProduct(ctx context.Context, id string) (*types.Product, error) {
+	b.calls.Add(1)
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.MemoryBackend.GetProduct(ctx, id)
+}
+
+var cacheProducts = []types.Product{
+	{ID: "p1", Name: "Tee", Active: true},
+	{ID: "p2", Name: "Mug", Active: true},
+}
+
+func newCountingCache(cfg CacheConfig) (*CachedBackend, *countingBackend) {
+	next := &countingBackend{MemoryBackend: NewMemoryBackend(cacheProducts, nil)}
+	return NewCachedBackend(next, cfg), next
+}
+
+// expire makes every cached result stale.
+func expire(b *CachedBackend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, el := range b.entries {
+		el.Value.(*cacheEntry).expires = time.Time{}
+	}
+}
+
+func TestCacheHitsAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		cfg   CacheConfig
+		stale bool
+		want  int32 // backend calls for two identical lists
+	}{
+		{"fresh", DefaultCacheConfig, false, 1},
+		{"expired", DefaultCacheConfig, true, 2},
+		{"zero ttl", CacheConfig{MaxEntries: 10}, false, 2},
+		{"no entries", CacheConfig{ProductListTTL: time.Minute}, false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, next := newCountingCache(tt.cfg)
+			if _, _, err := b.ListProducts(ctx, types.ProductListParams{Take: 10}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.stale {
+				expire(b)
+			}
+			ps, n, err := b.ListProducts(ctx, types.ProductListParams{Take: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ps) != 2 || n != 2 {
+				t.Errorf("got %d products, total %d", len(ps), n)
+			}
+			if got := next.calls.Load(); got != tt.want {
+				t.Errorf("backend called %d times, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheReturnsCopies(t *testing.T) {
+	b, _ := newCountingCache(DefaultCacheConfig)
+	ctx := context.Background()
+	ps, _, _ := b.ListProducts(ctx, types.ProductListParams{Take: 10})
+	ps[0].Name = "changed"
+	p, _ := b.GetProduct(ctx, "p1")
+	p.Name = "changed"
+
+	ps, _, _ = b.ListProducts(ctx, types.ProductListParams{Take: 10})
+	p, _ = b.GetProduct(ctx, "p1")
+	if ps[0].Name != "Tee" || p.Name != "Tee" {
+		t.Errorf("callers changed the cached results: %q, %q", ps[0].Name, p.Name)
+	}
+}
+
+func TestCacheStaleFallback(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		err    error
+		warm   bool // list products before the backend fails
+		wantOK bool
+	}{
+		{"unavailable, cached", ErrUnavailable, true, true},
+		{"unavailable, nothing cached", ErrUnavailable, false, false},
+		{"not found is not masked", ErrNotFound, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, next := newCountingCache(DefaultCacheConfig)
+			if tt.warm {
+				if _, _, err := b.ListProducts(ctx, types.ProductListParams{Take: 10}); err != nil {
+					t.Fatal(err)
+				}
+				expire(b)
+			}
+			next.err = tt.err
+
+			ps, _, err := b.ListProducts(ctx, types.ProductListParams{Take: 10})
+			if ok := err == nil && len(ps) == 2; ok != tt.wantOK {
+				t.Errorf("list: %d products, err %v", len(ps), err)
+			}
+			// GetProduct falls back to the listings the product appeared in.
+			p, err := b.GetProduct(ctx, "p2")
+			if ok := err == nil && p.ID == "p2"; ok != tt.wantOK {
+				t.Errorf("get: %v, err %v", p, err)
+			}
+			if err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCacheCoalescesConcurrentCalls(t *testing.T) {
+	b, next := newCountingCache(DefaultCacheConfig)
+	next.gate = make(chan struct{})
+
+	const callers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := b.ListProducts(context.Background(), types.ProductListParams{Take: 10})
+			errs <- err
+		}()
+	}
+	// Let every caller join the in-flight call before it returns.
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(next.gate)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := next.calls.Load(); got != 1 {
+		t.Errorf("backend called %d times, want 1", got)
+	}
+}
+
+func TestCacheCallerCancellation(t *testing.T) {
+	b, next := newCountingCache(DefaultCacheConfig)
+	next.gate = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := b.ListProducts(ctx, types.ProductListParams{Take: 10}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	// The shared fetch carries on without the caller and fills the cache.
+	close(next.gate)
+	if _, _, err := b.ListProducts(context.Background(), types.ProductListParams{Take: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if got := next.calls.Load(); got != 1 {
+		t.Errorf("backend called %d times, want 1", got)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	cfg := DefaultCacheConfig
+	cfg.MaxEntries = 2
+	b, next := newCountingCache(cfg)
+	ctx := context.Background()
+	list := func(take int) {
+		t.Helper()
+		if _, _, err := b.ListProducts(ctx, types.ProductListParams{Take: take}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list(1)
+	list(2)
+	list(1) // most recently used again
+	list(3) // evicts take=2
+	if n := b.lru.Len(); n != 2 {
+		t.Fatalf("%d entries cached, want 2", n)
+	}
+	calls := next.calls.Load()
+	list(1)
+	list(3)
+	if got := next.calls.Load(); got != calls {
+		t.Errorf("recently used results were evicted")
+	}
+	list(2)
+	if got := next.calls.Load(); got != calls+1 {
+		t.Errorf("least recently used result was kept")
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		invalidate func(*CachedBackend)
+		want       int32 // backend calls to refill a list and a product
+	}{
+		{"everything", func(b *CachedBackend) { b.Invalidate() }, 2},
+		{"one operation", func(b *CachedBackend) { b.Invalidate("product.get") }, 1},
+		{"other operation", func(b *CachedBackend) { b.Invalidate("category.list") }, 0},
+		{"product", func(b *CachedBackend) { b.InvalidateProduct("p1") }, 2},
+		{"other product", func(b *CachedBackend) { b.InvalidateProduct("p2") }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, next := newCountingCache(DefaultCacheConfig)
+			fill := func() {
+				b.ListProducts(ctx, types.ProductListParams{Take: 10})
+				b.GetProduct(ctx, "p1")
+			}
+			fill()
+			before := next.calls.Load()
+			tt.invalidate(b)
+			fill()
+			if got := next.calls.Load() - before; got != tt.want {
+				t.Errorf("%d backend calls after invalidating, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUncached(t *testing.T) {
+	mem := NewMemoryBackend(cacheProducts, nil)
+	cached := NewCachedBackend(NewCachedBackend(mem, DefaultCacheConfig), DefaultCacheConfig)
+	if got := Uncached(cached); got != Backend(mem) {
+		t.Errorf("Uncached = %T, want the memory backend", got)
+	}
+	if got := Uncached(mem); got != Backend(mem) {
+		t.Errorf("Uncached changed an uncached backend")
+	}
+}
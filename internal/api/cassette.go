@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"terminal-echoware/internal/audit"
+)
+
+// Interaction is one recorded request/response exchange. Cassette files
+// hold one Interaction per line. Bodies are stored with customer PII masked
+// (see audit.Redact), so cassettes are safe to attach to bug reports.
+type Interaction struct {
+	Request  json.RawMessage `json:"request"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// volatileParams are request params that differ on every run and are
+// ignored when matching a request against a cassette.
+var volatileParams = []string{"timestamp", "idempotencyKey"}
+
+// RecordingTransport passes requests to Base and appends every exchange to
+// a cassette file.
+type RecordingTransport struct {
+	Base http.RoundTripper
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+func NewRecordingTransport(base http.RoundTripper, path string) (*RecordingTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open cassette: %w", err)
+	}
+	return &RecordingTransport{Base: base, f: f}, nil
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := json.Marshal(Interaction{
+		Request:  redactBody(reqBody),
+		Status:   resp.StatusCode,
+		Response: redactBody(respBody),
+	})
+	if err == nil {
+		t.mu.Lock()
+		_, err = t.f.Write(append(line, '\n'))
+		t.mu.Unlock()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("record interaction: %w", err)
+	}
+	return resp, nil
+}
+
+func (t *RecordingTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.f.Close()
+}
+
+// ReplayTransport answers requests from a cassette without touching the
+// network. Requests are matched on operation and params; identical requests
+// get their recorded responses in order, and the last one repeats once the
+// recording runs out.
+type ReplayTransport struct {
+	mu     sync.Mutex
+	byKey  map[string][]Interaction
+	served map[string]int
+}
+
+func LoadReplayTransport(path string) (*ReplayTransport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open cassette: %w", err)
+	}
+	defer f.Close()
+
+	t := &ReplayTransport{byKey: make(map[string][]Interaction), served: make(map[string]int)}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var in Interaction
+		if err := json.Unmarshal(sc.Bytes(), &in); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", n, err)
+		}
+		key, err := interactionKey(in.Request)
+		if err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", n, err)
+		}
+		t.byKey[key] = append(t.byKey[key], in)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	return t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key, err := interactionKey(redactBody(body))
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	recorded := t.byKey[key]
+	i := t.served[key]
+	if i < len(recorded)-1 {
+		t.served[key] = i + 1
+	}
+	t.mu.Unlock()
+
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("replay: no recorded interaction for %s", key)
+	}
+	in := recorded[min(i, len(recorded)-1)]
+	return &http.Response{
+		Status:        strconv.Itoa(in.Status) + " " + http.StatusText(in.Status),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(in.Response)),
+		ContentLength: int64(len(in.Response)),
+		Request:       req,
+	}, nil
+}
+
+// redactBody masks PII in a JSON body. Bodies that are not JSON (a proxy's
+// HTML error page, say) are kept verbatim as a JSON string.
+func redactBody(body []byte) json.RawMessage {
+	if redacted := audit.Redact(json.RawMessage(body)); redacted != nil {
+		return redacted
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+// interactionKey canonicalises a request body: volatile params are dropped
+// and keys are sorted by re-encoding through a map.
+func interactionKey(body json.RawMessage) (string, error) {
+	var req map[string]any
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", fmt.Errorf("parse request: %w", err)
+	}
+	if params, ok := req["params"].(map[string]any); ok {
+		for _, k := range volatileParams {
+			delete(params, k)
+		}
+	}
+	key, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// readBody drains *body and replaces it with an in-memory copy so it can be
+// read again downstream.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// post sends body through rt and returns the status and response body.
+func post(t *testing.T, rt http.RoundTripper, url, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"missing"`) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"n":%d,"email":"asha@example.com"}}`, n)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "session.cassette")
+	rec, err := NewRecordingTransport(nil, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := `{"type":"product","operation":"list","params":{"skip":0}}`
+	order := `{"type":"order","operation":"create","params":{"email":"asha@example.com","timestamp":"%s","idempotencyKey":"%s"}}`
+	missing := `{"type":"product","operation":"get","params":{"id":"missing"}}`
+
+	// Recording passes the real responses through untouched.
+	if _, body := post(t, rec, srv.URL, list); !strings.Contains(body, "asha@example.com") {
+		t.Errorf("recording changed the response: %s", body)
+	}
+	post(t, rec, srv.URL, list)
+	post(t, rec, srv.URL, fmt.Sprintf(order, "2026-01-01T00:00:00Z", "k1"))
+	post(t, rec, srv.URL, missing)
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "asha@example.com") {
+		t.Errorf("cassette holds unmasked PII:\n%s", data)
+	}
+
+	replay, err := LoadReplayTransport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := hits.Load()
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantN      int // the "n" in the response; 0 to skip the check
+	}{
+		{"first of a repeat", list, 200, 1},
+		{"second of a repeat", list, 200, 2},
+		{"last one repeats", list, 200, 2},
+		{"volatile params ignored", fmt.Sprintf(order, "2027-06-01T10:00:00Z", "k2"), 200, 3},
+		{"recorded error", missing, 404, 0},
+	}
+	for _, tt := range tests {
+		status, body := post(t, replay, "http://replay.invalid", tt.body)
+		if status != tt.wantStatus {
+			t.Errorf("%s: status %d, want %d", tt.name, status, tt.wantStatus)
+		}
+		if tt.wantN == 0 {
+			continue
+		}
+		var resp struct {
+			Data struct{ N int } `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(body), &resp); err != nil || resp.Data.N != tt.wantN {
+			t.Errorf("%s: response %s, want n=%d", tt.name, body, tt.wantN)
+		}
+	}
+	if hits.Load() != recorded {
+		t.Error("replay reached the server")
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://replay.invalid", strings.NewReader(`{"type":"product","operation":"get","params":{"id":"p9"}}`))
+	if _, err := replay.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("unrecorded request: error = %v", err)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{"params":{"phone":"9876543210"}}`, `{"params":{"phone":"***10"}}`},
+		{`502 bad gateway`, `"502 bad gateway"`},
+	}
+	for _, tt := range tests {
+		if got := string(redactBody([]byte(tt.in))); got != tt.want {
+			t.Errorf("redactBody(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadReplayTransportErrors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.cassette")
+	os.WriteFile(bad, []byte("\n{not json}\n"), 0o600)
+
+	if _, err := LoadReplayTransport(filepath.Join(dir, "none")); err == nil {
+		t.Error("missing cassette loaded")
+	}
+	if _, err := LoadReplayTransport(bad); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("bad cassette: error = %v", err)
+	}
+}
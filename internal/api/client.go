@@ -2,42 +2,94 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
+	"sync/atomic"
 	"time"
+	"terminal-echoware/internal/audit"
 	"terminal-echoware/pkg/types"
 )
 
 type Client struct {
-	BaseURL    string
+	// baseURL holds a string; it can be swapped with SetBaseURL while calls
+	// are in flight.
+	baseURL    atomic.Value
 	HTTPClient *http.Client
+	// Timeouts bounds each attempt of an operation; operations without an
+	// entry use DefaultTimeout. A shorter deadline on the caller's context
+	// still wins.
+	Timeouts map[string]time.Duration
+	Retry    RetryPolicy
+	// Breaker stops calls for a while after repeated unavailable-class
+	// failures; nil disables it.
+	Breaker *Breaker
+	// Audit records every call with its outcome; mutations also carry their
+	// redacted params. nil disables auditing.
+	Audit *audit.Logger
 }
 
-const orderLogPath = "/tmp/terminal-echoware-order.log"
+const DefaultTimeout = 15 * time.Second
 
-func appendOrderLog(entry string) {
-	f, err := os.OpenFile(orderLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return
-	}
-	defer f.Close()
-	timestamp := time.Now().Format(time.RFC3339)
-	_, _ = f.WriteString(fmt.Sprintf("[%s] %s\n", timestamp, entry))
+// DefaultOperationTimeouts are the per-operation deadlines NewClient starts with.
+var DefaultOperationTimeouts = map[string]time.Duration{
+	"product.list":   10 * time.Second,
+	"product.get":    8 * time.Second,
+	"product.search": 10 * time.Second,
+	"category.list":  8 * time.Second,
+	"order.create":   20 * time.Second,
 }
 
 func NewClient(baseURL string) *Client {
-	return &Client{
-		BaseURL:    normalizeBaseURL(baseURL),
-		HTTPClient: &http.Client{},
+	timeouts := make(map[string]time.Duration, len(DefaultOperationTimeouts))
+	for op, d := range DefaultOperationTimeouts {
+		timeouts[op] = d
+	}
+	c := &Client{
+		// Hard ceiling in case a caller passes a context without a deadline
+		// and the operation has no timeout configured.
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		Timeouts:   timeouts,
+		Retry:      DefaultRetryPolicy,
+		Breaker:    NewBreaker(DefaultBreakerConfig),
+	}
+	c.baseURL.Store(normalizeBaseURL(baseURL))
+	return c
+}
+
+func (c *Client) BaseURL() string {
+	url, _ := c.baseURL.Load().(string)
+	return url
+}
+
+// SetBaseURL points the client at another backend. Calls already in flight
+// finish against the old one; the breaker starts afresh.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL.Store(normalizeBaseURL(baseURL))
+	c.Breaker.Reset()
+}
+
+func (c *Client) timeoutFor(operation string) time.Duration {
+	if d, ok := c.Timeouts[operation]; ok && d > 0 {
+		return d
 	}
+	return DefaultTimeout
+}
+
+func (c *Client) CallAPI(ctx context.Context, req types.APIRequest) (*types.APIResponse, error) {
+	return c.do(ctx, req, "")
 }
 
-func (c *Client) CallAPI(req types.APIRequest) (*types.APIResponse, error) {
-	if c.BaseURL == "" {
+// do sends req, retrying transient failures according to c.Retry.
+// Mutations are retried only when idempotencyKey is set; the same key is
+// sent on every attempt.
+func (c *Client) do(ctx context.Context, req types.APIRequest, idempotencyKey string) (*types.APIResponse, error) {
+	baseURL := c.BaseURL()
+	if baseURL == "" {
 		return nil, fmt.Errorf("missing api base url")
 	}
 	jsonData, err := json.Marshal(req)
@@ -45,45 +97,116 @@ func (c *Client) CallAPI(req types.APIRequest) (*types.APIResponse, error) {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", c.BaseURL, bytes.NewBuffer(jsonData))
+	start := time.Now()
+	if err := c.Breaker.Allow(); err != nil {
+		err = &APIError{Op: req.Operation, Err: err}
+		c.audit(ctx, req, start, err)
+		return nil, err
+	}
+	resp, err := c.retry(ctx, baseURL, req, jsonData, idempotencyKey)
+	c.Breaker.Record(err)
+	c.audit(ctx, req, start, err)
+	return resp, err
+}
+
+func (c *Client) audit(ctx context.Context, req types.APIRequest, start time.Time, err error) {
+	if c.Audit == nil {
+		return
+	}
+	entry := audit.Entry{
+		Time:       start,
+		SessionID:  audit.SessionID(ctx),
+		Operation:  req.Operation,
+		DurationMS: time.Since(start).Milliseconds(),
+		Status:     "ok",
+	}
+	if req.Type == types.OperationTypeMutation {
+		entry.Params = audit.Redact(req.Params)
+	}
+	if err != nil {
+		entry.Status = "error"
+		entry.Error = err.Error()
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			entry.HTTPStatus = apiErr.StatusCode
+		}
+	}
+	c.Audit.Log(entry)
+}
+
+func (c *Client) retry(ctx context.Context, baseURL string, req types.APIRequest, jsonData []byte, idempotencyKey string) (*types.APIResponse, error) {
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 || (req.Type == types.OperationTypeMutation && idempotencyKey == "") {
+		attempts = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.roundTrip(ctx, baseURL, req.Operation, jsonData, idempotencyKey)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt+1 >= attempts || !isRetryable(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		if sleepErr := sleepContext(ctx, c.Retry.backoff(attempt)); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) roundTrip(ctx context.Context, baseURL, operation string, jsonData []byte, idempotencyKey string) (*types.APIResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeoutFor(operation))
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", baseURL, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		httpReq.Header.Set(IdempotencyHeader, idempotencyKey)
+	}
 
 	resp, err := c.HTTPClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("do request: %w", err)
+		return nil, &APIError{Op: operation, Retryable: true, Err: err}
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		appendOrderLog(fmt.Sprintf("http %d response body: %s", resp.StatusCode, string(body)))
+		return nil, &APIError{Op: operation, StatusCode: resp.StatusCode, Retryable: true, Err: fmt.Errorf("read response: %w", err)}
 	}
 
 	var apiResp types.APIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("unmarshal response: %w", err)
-	}
-
-	if apiResp.Error != "" {
-		appendOrderLog("api error response: " + string(body))
-		return nil, fmt.Errorf("api error: %s", apiResp.Error)
+		return nil, &APIError{
+			Op:         operation,
+			StatusCode: resp.StatusCode,
+			Retryable:  transientStatus(resp.StatusCode),
+			Err:        fmt.Errorf("unmarshal response: %w", err),
+		}
 	}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("http error: %d - %s", resp.StatusCode, apiResp.Error)
+	if apiResp.Error != "" || resp.StatusCode >= 400 {
+		return nil, &APIError{
+			Op:         operation,
+			StatusCode: resp.StatusCode,
+			Message:    apiResp.Error,
+			Retryable:  transientStatus(resp.StatusCode),
+		}
 	}
 
 	return &apiResp, nil
 }
 
+// Degraded reports whether the circuit breaker currently considers the
+// backend unavailable.
+func (c *Client) Degraded() bool {
+	return c.Breaker.Open()
+}
+
 func normalizeBaseURL(baseURL string) string {
 	baseURL = strings.TrimSpace(baseURL)
 	if baseURL == "" {
@@ -95,146 +218,142 @@ func normalizeBaseURL(baseURL string) string {
 	return "http://" + baseURL
 }
 
-func (c *Client) ListProducts(params types.ProductListParams) ([]types.Product, int, error) {
-	req := types.APIRequest{
-		Type:      types.OperationTypeQuery,
-		Operation: "product.list",
-		Params:    params,
-	}
-
-	resp, err := c.CallAPI(req)
+// call sends a single operation and decodes the response data straight into
+// T, so each payload is parsed exactly once.
+func call[T any](ctx context.Context, c *Client, typ types.OperationType, operation string, params any) (T, int, error) {
+	resp, err := c.do(ctx, types.APIRequest{Type: typ, Operation: operation, Params: params}, "")
 	if err != nil {
-		return nil, 0, err
+		var zero T
+		return zero, 0, err
 	}
+	return decode[T](operation, resp)
+}
 
-	productsData, ok := resp.Data.([]interface{})
-	if !ok {
-		productsJSON, _ := json.Marshal(resp.Data)
-		var products []types.Product
-		if err := json.Unmarshal(productsJSON, &products); err != nil {
-			return nil, 0, fmt.Errorf("unmarshal products: %w", err)
-		}
-		return products, resp.Count, nil
+func decode[T any](operation string, resp *types.APIResponse) (T, int, error) {
+	var out T
+	if len(resp.Data) == 0 {
+		return out, resp.Count, nil
 	}
-
-	productsJSON, _ := json.Marshal(productsData)
-	var products []types.Product
-	if err := json.Unmarshal(productsJSON, &products); err != nil {
-		return nil, 0, fmt.Errorf("unmarshal products: %w", err)
+	if err := json.Unmarshal(resp.Data, &out); err != nil {
+		return out, 0, fmt.Errorf("%s: decode data: %w", operation, err)
 	}
-
-	return products, resp.Count, nil
+	return out, resp.Count, nil
 }
 
-func (c *Client) GetProduct(id string) (*types.Product, error) {
-	req := types.APIRequest{
-		Type:      types.OperationTypeQuery,
-		Operation: "product.get",
-		Params:    types.ProductGetParams{ID: id},
-	}
+func (c *Client) ListProducts(ctx context.Context, params types.ProductListParams) ([]types.Product, int, error) {
+	return call[[]types.Product](ctx, c, types.OperationTypeQuery, "product.list", params)
+}
 
-	resp, err := c.CallAPI(req)
+func (c *Client) GetProduct(ctx context.Context, id string) (*types.Product, error) {
+	product, _, err := call[types.Product](ctx, c, types.OperationTypeQuery, "product.get", types.ProductGetParams{ID: id})
 	if err != nil {
 		return nil, err
 	}
-
-	productJSON, _ := json.Marshal(resp.Data)
-	var product types.Product
-	if err := json.Unmarshal(productJSON, &product); err != nil {
-		return nil, fmt.Errorf("unmarshal product: %w", err)
-	}
-
 	return &product, nil
 }
 
-func (c *Client) SearchProducts(params types.ProductSearchParams) ([]types.Product, int, error) {
-	req := types.APIRequest{
-		Type:      types.OperationTypeQuery,
-		Operation: "product.search",
-		Params:    params,
-	}
+func (c *Client) SearchProducts(ctx context.Context, params types.ProductSearchParams) ([]types.Product, int, error) {
+	return call[[]types.Product](ctx, c, types.OperationTypeQuery, "product.search", params)
+}
 
-	resp, err := c.CallAPI(req)
+func (c *Client) CreateProduct(ctx context.Context, params types.ProductCreateParams) (*types.Product, error) {
+	product, _, err := call[types.Product](ctx, c, types.OperationTypeMutation, "product.create", params)
 	if err != nil {
-		return nil, 0, err
-	}
-
-	productsData, ok := resp.Data.([]interface{})
-	if !ok {
-		productsJSON, _ := json.Marshal(resp.Data)
-		var products []types.Product
-		if err := json.Unmarshal(productsJSON, &products); err != nil {
-			return nil, 0, fmt.Errorf("unmarshal products: %w", err)
-		}
-		return products, resp.Count, nil
+		return nil, err
 	}
+	return &product, nil
+}
 
-	productsJSON, _ := json.Marshal(productsData)
-	var products []types.Product
-	if err := json.Unmarshal(productsJSON, &products); err != nil {
-		return nil, 0, fmt.Errorf("unmarshal products: %w", err)
+// UpdateProduct applies the non-nil fields of params to the product with
+// params.ID and returns the updated product.
+func (c *Client) UpdateProduct(ctx context.Context, params types.ProductUpdateParams) (*types.Product, error) {
+	product, _, err := call[types.Product](ctx, c, types.OperationTypeMutation, "product.update", params)
+	if err != nil {
+		return nil, err
 	}
+	return &product, nil
+}
 
-	return products, resp.Count, nil
+func (c *Client) DeleteProduct(ctx context.Context, id string) error {
+	_, _, err := call[json.RawMessage](ctx, c, types.OperationTypeMutation, "product.delete", types.ProductDeleteParams{ID: id})
+	return err
 }
 
-func (c *Client) ListCategories(params types.CategoryListParams) ([]types.Category, int, error) {
-	req := types.APIRequest{
-		Type:      types.OperationTypeQuery,
-		Operation: "category.list",
-		Params:    params,
-	}
+func (c *Client) ListCategories(ctx context.Context, params types.CategoryListParams) ([]types.Category, int, error) {
+	return call[[]types.Category](ctx, c, types.OperationTypeQuery, "category.list", params)
+}
 
-	resp, err := c.CallAPI(req)
+func (c *Client) GetCategory(ctx context.Context, id string) (*types.Category, error) {
+	category, _, err := call[types.Category](ctx, c, types.OperationTypeQuery, "category.get", types.CategoryGetParams{ID: id})
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	return &category, nil
+}
 
-	categoriesData, ok := resp.Data.([]interface{})
-	if !ok {
-		categoriesJSON, _ := json.Marshal(resp.Data)
-		var categories []types.Category
-		if err := json.Unmarshal(categoriesJSON, &categories); err != nil {
-			return nil, 0, fmt.Errorf("unmarshal categories: %w", err)
-		}
-		return categories, resp.Count, nil
+func (c *Client) CreateCategory(ctx context.Context, params types.CategoryCreateParams) (*types.Category, error) {
+	category, _, err := call[types.Category](ctx, c, types.OperationTypeMutation, "category.create", params)
+	if err != nil {
+		return nil, err
 	}
+	return &category, nil
+}
 
-	categoriesJSON, _ := json.Marshal(categoriesData)
-	var categories []types.Category
-	if err := json.Unmarshal(categoriesJSON, &categories); err != nil {
-		return nil, 0, fmt.Errorf("unmarshal categories: %w", err)
+// UpdateCategory applies the non-nil fields of params, including the
+// category discount, and returns the updated category.
+func (c *Client) UpdateCategory(ctx context.Context, params types.CategoryUpdateParams) (*types.Category, error) {
+	category, _, err := call[types.Category](ctx, c, types.OperationTypeMutation, "category.update", params)
+	if err != nil {
+		return nil, err
 	}
+	return &category, nil
+}
 
-	return categories, resp.Count, nil
+func (c *Client) DeleteCategory(ctx context.Context, id string) error {
+	_, _, err := call[json.RawMessage](ctx, c, types.OperationTypeMutation, "category.delete", types.CategoryDeleteParams{ID: id})
+	return err
 }
 
-func (c *Client) CreateOrder(params types.OrderCreateParams) (*types.Order, error) {
+func (c *Client) CreateOrder(ctx context.Context, params types.OrderCreateParams) (*types.Order, error) {
 	req := types.APIRequest{
 		Type:      types.OperationTypeMutation,
 		Operation: "order.create",
 		Params:    params,
 	}
 
-	if payload, err := json.Marshal(params); err == nil {
-		appendOrderLog("order.create request: " + string(payload))
+	resp, err := c.do(ctx, req, params.IdempotencyKey)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.CallAPI(req)
+	order, _, err := decode[types.Order]("order.create", resp)
 	if err != nil {
-		appendOrderLog("order.create error: " + err.Error())
 		return nil, err
 	}
+	return &order, nil
+}
 
-	if payload, err := json.Marshal(resp.Data); err == nil {
-		appendOrderLog("order.create response: " + string(payload))
-	}
+func (c *Client) ListOrders(ctx context.Context, params types.OrderListParams) ([]types.Order, int, error) {
+	return call[[]types.Order](ctx, c, types.OperationTypeQuery, "order.list", params)
+}
 
-	orderJSON, _ := json.Marshal(resp.Data)
-	var order types.Order
-	if err := json.Unmarshal(orderJSON, &order); err != nil {
-		return nil, fmt.Errorf("unmarshal order: %w", err)
+func (c *Client) GetOrder(ctx context.Context, id string) (*types.Order, error) {
+	order, _, err := call[types.Order](ctx, c, types.OperationTypeQuery, "order.get", types.OrderGetParams{ID: id})
+	if err != nil {
+		return nil, err
 	}
+	return &order, nil
+}
 
+// UpdateOrderStatus moves an order to a new status and returns the updated
+// order. Unknown statuses are rejected before anything is sent.
+func (c *Client) UpdateOrderStatus(ctx context.Context, params types.OrderUpdateStatusParams) (*types.Order, error) {
+	if !params.Status.Type.Valid() {
+		return nil, validationError("order.update_status", "unknown status %q", params.Status.Type)
+	}
+	order, _, err := call[types.Order](ctx, c, types.OperationTypeMutation, "order.update_status", params)
+	if err != nil {
+		return nil, err
+	}
 	return &order, nil
 }
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"terminal-echoware/pkg/types"
+)
+
+// attempt is one request as the test server saw it.
+type attempt struct {
+	op  string
+	key string
+}
+
+// flakyServer fails the first failures requests with status, then answers
+// with data.
+func flakyServer(t *testing.T, failures, status int, data string) (*httptest.Server, func() []attempt) {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []attempt
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req types.APIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		seen = append(seen, attempt{op: req.Operation, key: r.Header.Get(IdempotencyHeader)})
+		n := len(seen)
+		mu.Unlock()
+		if n <= failures {
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"error":"try again"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":%s}`, data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []attempt {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]attempt(nil), seen...)
+	}
+}
+
+func testClient(url string) *Client {
+	c := NewClient(url)
+	c.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	c.Breaker = nil
+	return c
+}
+
+func TestClientRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		status       int
+		call         func(*Client) error
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name: "query recovers", failures: 2, status: http.StatusServiceUnavailable,
+			call:         func(c *Client) error { _, err := c.GetProduct(context.Background(), "p1"); return err },
+			wantAttempts: 3,
+		},
+		{
+			name: "query gives up", failures: 5, status: http.StatusBadGateway,
+			call:         func(c *Client) error { _, err := c.GetProduct(context.Background(), "p1"); return err },
+			wantAttempts: 3, wantErr: ErrUnavailable,
+		},
+		{
+			name: "rate limited", failures: 1, status: http.StatusTooManyRequests,
+			call:         func(c *Client) error { _, err := c.GetProduct(context.Background(), "p1"); return err },
+			wantAttempts: 2,
+		},
+		{
+			name: "not found is final", failures: 5, status: http.StatusNotFound,
+			call:         func(c *Client) error { _, err := c.GetProduct(context.Background(), "p1"); return err },
+			wantAttempts: 1, wantErr: ErrNotFound,
+		},
+		{
+			name: "validation is final", failures: 5, status: http.StatusUnprocessableEntity,
+			call:         func(c *Client) error { _, err := c.GetProduct(context.Background(), "p1"); return err },
+			wantAttempts: 1, wantErr: ErrValidation,
+		},
+		{
+			name: "keyed order recovers", failures: 2, status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				_, err := c.CreateOrder(context.Background(), types.OrderCreateParams{IdempotencyKey: "k1"})
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name: "keyless order is not retried", failures: 1, status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				_, err := c.CreateOrder(context.Background(), types.OrderCreateParams{})
+				return err
+			},
+			wantAttempts: 1, wantErr: ErrUnavailable,
+		},
+		{
+			name: "other mutations are not retried", failures: 1, status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				_, err := c.CreateCategory(context.Background(), types.CategoryCreateParams{Name: "x"})
+				return err
+			},
+			wantAttempts: 1, wantErr: ErrUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, seen := flakyServer(t, tt.failures, tt.status, `{"_id":"x"}`)
+			err := tt.call(testClient(srv.URL))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got := len(seen()); got != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClientRetrySendsSameIdempotencyKey(t *testing.T) {
+	srv, seen := flakyServer(t, 2, http.StatusServiceUnavailable, `{"_id":"o1"}`)
+	order, err := testClient(srv.URL).CreateOrder(context.Background(), types.OrderCreateParams{IdempotencyKey: "order-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "o1" {
+		t.Errorf("order = %+v", order)
+	}
+	attempts := seen()
+	if len(attempts) != 3 {
+		t.Fatalf("%d attempts, want 3", len(attempts))
+	}
+	for i, a := range attempts {
+		if a.op != "order.create" || a.key != "order-key" {
+			t.Errorf("attempt %d = %+v, want order.create with the same key", i, a)
+		}
+	}
+
+	srv, seen = flakyServer(t, 0, 0, `{"_id":"p1"}`)
+	testClient(srv.URL).GetProduct(context.Background(), "p1")
+	if key := seen()[0].key; key != "" {
+		t.Errorf("query sent Idempotency-Key %q", key)
+	}
+}
+
+func TestClientRetryStopsOnCancel(t *testing.T) {
+	srv, seen := flakyServer(t, 5, http.StatusServiceUnavailable, `{}`)
+	c := testClient(srv.URL)
+	c.Retry = RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetProduct(ctx, "p1")
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("error = %v, want the last attempt's", err)
+	}
+	if time.Since(start) > 5*time.Second || len(seen()) != 1 {
+		t.Errorf("kept retrying after the context ended: %d attempts", len(seen()))
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second},
+		{62, time.Second}, // the shift overflows
+	}
+	for _, tt := range tests {
+		for range 200 {
+			if d := p.backoff(tt.attempt); d < 0 || d >= tt.max {
+				t.Fatalf("backoff(%d) = %v, want within [0, %v)", tt.attempt, d, tt.max)
+			}
+		}
+	}
+	if d := (RetryPolicy{}).backoff(3); d != 0 {
+		t.Errorf("zero policy backoff = %v", d)
+	}
+}
+
+func TestClientBreakerFailsFast(t *testing.T) {
+	srv, seen := flakyServer(t, 100, http.StatusServiceUnavailable, `{}`)
+	c := testClient(srv.URL)
+	c.Retry = RetryPolicy{MaxAttempts: 1}
+	c.Breaker = NewBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+
+	for range 2 {
+		c.GetProduct(context.Background(), "p1")
+	}
+	_, err := c.GetProduct(context.Background(), "p1")
+	if !errors.Is(err, ErrUnavailable) || !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("error = %v, want ErrCircuitOpen reported as ErrUnavailable", err)
+	}
+	if len(seen()) != 2 || !c.Degraded() {
+		t.Errorf("%d requests sent, degraded = %v", len(seen()), c.Degraded())
+	}
+
+	c.SetBaseURL(srv.URL)
+	if c.Degraded() {
+		t.Error("SetBaseURL kept the breaker open")
+	}
+}
+
+func TestClientDecode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"_id":"p1","selling_price":"799.00"},{"_id":"p2","selling_price":349.5}],"count":7}`)
+	}))
+	defer srv.Close()
+	products, total, err := testClient(srv.URL).ListProducts(context.Background(), types.ProductListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 7 || len(products) != 2 || products[1].SellingPrice.Minor != 34950 {
+		t.Errorf("got %d products, total %d: %+v", len(products), total, products)
+	}
+	if _, err := NewClient("").GetProduct(context.Background(), "p1"); err == nil {
+		t.Error("client without a base URL sent a request")
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the broad failure classes callers react to. Match them
+// with errors.Is; an *APIError reports the class it belongs to.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("backend unavailable")
+)
+
+// APIError describes a failed backend operation.
+type APIError struct {
+	Op         string // operation name, e.g. "product.get"
+	StatusCode int    // HTTP status; 0 when no response was received
+	Message    string // error string reported by the backend, if any
+	Retryable  bool   // whether repeating the same request may succeed
+	Err        error  // underlying transport or decode error, if any
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, ": http %d", e.StatusCode)
+	}
+	switch {
+	case e.Message != "":
+		b.WriteString(": " + e.Message)
+	case e.Err != nil:
+		b.WriteString(": " + e.Err.Error())
+	case e.StatusCode != 0:
+		b.WriteString(": " + strings.ToLower(http.StatusText(e.StatusCode)))
+	}
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel for e's failure class.
+func (e *APIError) Is(target error) bool {
+	return target != nil && target == e.class()
+}
+
+func (e *APIError) class() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusBadRequest,
+		e.StatusCode == http.StatusUnprocessableEntity,
+		e.StatusCode == http.StatusConflict:
+		return ErrValidation
+	case e.StatusCode == 0 && e.Err != nil,
+		transientStatus(e.StatusCode):
+		return ErrUnavailable
+	case e.StatusCode == http.StatusOK && strings.Contains(strings.ToLower(e.Message), "not found"):
+		// The backend sometimes reports missing records with a 200 and an
+		// error string.
+		return ErrNotFound
+	}
+	return nil
+}
+
+func transientStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+func isRetryable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Retryable
+}
+
+func notFoundError(op, format string, args ...any) error {
+	return &APIError{Op: op, StatusCode: http.StatusNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func validationError(op, format string, args ...any) error {
+	return &APIError{Op: op, StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorClass(t *testing.T) {
+	transport := errors.New("connection refused")
+	tests := []struct {
+		name string
+		err  error
+		want error // nil for no class
+	}{
+		{"404", &APIError{StatusCode: http.StatusNotFound}, ErrNotFound},
+		{"400", &APIError{StatusCode: http.StatusBadRequest}, ErrValidation},
+		{"409", &APIError{StatusCode: http.StatusConflict}, ErrValidation},
+		{"422", &APIError{StatusCode: http.StatusUnprocessableEntity}, ErrValidation},
+		{"429", &APIError{StatusCode: http.StatusTooManyRequests}, ErrUnavailable},
+		{"500", &APIError{StatusCode: http.StatusInternalServerError}, ErrUnavailable},
+		{"503", &APIError{StatusCode: http.StatusServiceUnavailable}, ErrUnavailable},
+		{"transport", &APIError{Err: transport}, ErrUnavailable},
+		{"timeout", &APIError{Err: context.DeadlineExceeded}, ErrUnavailable},
+		{"circuit open", &APIError{Err: ErrCircuitOpen}, ErrUnavailable},
+		{"200 not found", &APIError{StatusCode: http.StatusOK, Message: "Product Not Found"}, ErrNotFound},
+		{"200 other error", &APIError{StatusCode: http.StatusOK, Message: "bad things"}, nil},
+		{"401", &APIError{StatusCode: http.StatusUnauthorized}, nil},
+		{"wrapped", fmt.Errorf("load: %w", &APIError{StatusCode: http.StatusNotFound}), ErrNotFound},
+	}
+	classes := []error{ErrNotFound, ErrValidation, ErrUnavailable}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, class := range classes {
+				if got := errors.Is(tt.err, class); got != (class == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", tt.err, class, got)
+				}
+			}
+		})
+	}
+	if err := (&APIError{Err: transport}); !errors.Is(err, transport) {
+		t.Error("APIError does not unwrap to its cause")
+	}
+	if err := (&APIError{Err: ErrCircuitOpen}); !errors.Is(err, ErrCircuitOpen) {
+		t.Error("APIError does not unwrap to ErrCircuitOpen")
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *APIError
+		want string
+	}{
+		{&APIError{Op: "product.get", StatusCode: 404, Message: "no such product"}, "product.get: http 404: no such product"},
+		{&APIError{Op: "product.get", StatusCode: 503}, "product.get: http 503: service unavailable"},
+		{&APIError{Op: "order.create", Err: errors.New("connection refused")}, "order.create: connection refused"},
+		{&APIError{Op: "product.list"}, "product.list"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
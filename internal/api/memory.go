@@ -0,0 +1,460 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"terminal-echoware/pkg/types"
+)
+
+// MemoryBackend is an in-process Backend over a fixed catalog. Orders are
+// kept in memory only; repeated creates with the same idempotency key return
+// the original order.
+type MemoryBackend struct {
+	mu         sync.RWMutex
+	products   []types.Product
+	categories []types.Category
+	orders     []types.Order
+	orderKeys  map[string]int // idempotency key -> index into orders
+	nextID     int
+}
+
+func NewMemoryBackend(products []types.Product, categories []types.Category) *MemoryBackend {
+	return &MemoryBackend{
+		products:   slices.Clone(products),
+		categories: slices.Clone(categories),
+		orderKeys:  make(map[string]int),
+	}
+}
+
+// LoadMemoryBackend reads products.json and, if present, categories.json from
+// dir. Both files hold a JSON array in the backend's wire format.
+func LoadMemoryBackend(dir string) (*MemoryBackend, error) {
+	var products []types.Product
+	if err := readFixture(filepath.Join(dir, "products.json"), &products); err != nil {
+		return nil, err
+	}
+	var categories []types.Category
+	if err := readFixture(filepath.Join(dir, "categories.json"), &categories); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return NewMemoryBackend(products, categories), nil
+}
+
+func readFixture(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
+
+func (b *MemoryBackend) ListProducts(ctx context.Context, params types.ProductListParams) ([]types.Product, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var matched []types.Product
+	for _, p := range b.products {
+		if params.Active != nil && p.Active != *params.Active {
+			continue
+		}
+		if params.CategoryID != "" && !slices.Contains(p.Categories, params.CategoryID) {
+			continue
+		}
+		matched = append(matched, b.withCategories(p, params.IncludeCategories))
+	}
+	return page(matched, params.Skip, params.Take), len(matched), nil
+}
+
+func (b *MemoryBackend) GetProduct(ctx context.Context, id string) (*types.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, p := range b.products {
+		if p.ID == id {
+			p = b.withCategories(p, true)
+			return &p, nil
+		}
+	}
+	return nil, notFoundError("product.get", "product %s not found", id)
+}
+
+// SearchProducts matches products whose name, brand, tags or description
+// contain every whitespace-separated term of the query, case-insensitively.
+// Only active products are returned.
+func (b *MemoryBackend) SearchProducts(ctx context.Context, params types.ProductSearchParams) ([]types.Product, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	terms := strings.Fields(strings.ToLower(params.SearchTerm))
+	var matched []types.Product
+	for _, p := range b.products {
+		if !p.Active || !matchesSearch(p, terms) {
+			continue
+		}
+		matched = append(matched, b.withCategories(p, params.IncludeCategories))
+	}
+	return page(matched, params.Skip, params.Take), len(matched), nil
+}
+
+func (b *MemoryBackend) CreateProduct(ctx context.Context, params types.ProductCreateParams) (*types.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, validationError("product.create", "product name is required")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := types.Product{
+		ID:                 b.newID("prd"),
+		Name:               params.Name,
+		Brand:              params.Brand,
+		Categories:         params.Categories,
+		ProductDescription: params.ProductDescription,
+		MRPPrice:           params.MRPPrice,
+		SellingPrice:       params.SellingPrice,
+		Tags:               params.Tags,
+		Medias:             params.Medias,
+		Features:           params.Features,
+		Active:             params.Active,
+		ProductVariants:    params.ProductVariants,
+	}
+	b.products = append(b.products, p)
+	return &p, nil
+}
+
+func (b *MemoryBackend) UpdateProduct(ctx context.Context, params types.ProductUpdateParams) (*types.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := slices.IndexFunc(b.products, func(p types.Product) bool { return p.ID == params.ID })
+	if i < 0 {
+		return nil, notFoundError("product.update", "product %s not found", params.ID)
+	}
+	p := &b.products[i]
+	if params.Name != nil {
+		p.Name = *params.Name
+	}
+	if params.Brand != nil {
+		p.Brand = *params.Brand
+	}
+	if params.Categories != nil {
+		p.Categories = params.Categories
+	}
+	if params.ProductDescription != nil {
+		p.ProductDescription = *params.ProductDescription
+	}
+	if params.MRPPrice != nil {
+		p.MRPPrice = *params.MRPPrice
+	}
+	if params.SellingPrice != nil {
+		p.SellingPrice = *params.SellingPrice
+	}
+	if params.Tags != nil {
+		p.Tags = params.Tags
+	}
+	if params.Medias != nil {
+		p.Medias = params.Medias
+	}
+	if params.Features != nil {
+		p.Features = params.Features
+	}
+	if params.Active != nil {
+		p.Active = *params.Active
+	}
+	if params.ProductVariants != nil {
+		p.ProductVariants = params.ProductVariants
+	}
+	updated := *p
+	return &updated, nil
+}
+
+func (b *MemoryBackend) DeleteProduct(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := slices.IndexFunc(b.products, func(p types.Product) bool { return p.ID == id })
+	if i < 0 {
+		return notFoundError("product.delete", "product %s not found", id)
+	}
+	b.products = slices.Delete(b.products, i, i+1)
+	return nil
+}
+
+func (b *MemoryBackend) ListCategories(ctx context.Context, params types.CategoryListParams) ([]types.Category, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return page(b.categories, params.Skip, params.Limit), len(b.categories), nil
+}
+
+func (b *MemoryBackend) GetCategory(ctx context.Context, id string) (*types.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, c := range b.categories {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, notFoundError("category.get", "category %s not found", id)
+}
+
+func (b *MemoryBackend) CreateCategory(ctx context.Context, params types.CategoryCreateParams) (*types.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, validationError("category.create", "category name is required")
+	}
+	if err := validateDiscount("category.create", params.Discount); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := types.Category{
+		ID:          b.newID("cat"),
+		Name:        params.Name,
+		Description: params.Description,
+		Medias:      params.Medias,
+		Discount:    params.Discount,
+	}
+	b.categories = append(b.categories, c)
+	return &c, nil
+}
+
+func (b *MemoryBackend) UpdateCategory(ctx context.Context, params types.CategoryUpdateParams) (*types.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if params.Discount != nil {
+		if err := validateDiscount("category.update", *params.Discount); err != nil {
+			return nil, err
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := slices.IndexFunc(b.categories, func(c types.Category) bool { return c.ID == params.ID })
+	if i < 0 {
+		return nil, notFoundError("category.update", "category %s not found", params.ID)
+	}
+	c := &b.categories[i]
+	if params.Name != nil {
+		c.Name = *params.Name
+	}
+	if params.Description != nil {
+		c.Description = *params.Description
+	}
+	if params.Medias != nil {
+		c.Medias = params.Medias
+	}
+	if params.Discount != nil {
+		c.Discount = *params.Discount
+	}
+	updated := *c
+	return &updated, nil
+}
+
+func (b *MemoryBackend) DeleteCategory(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := slices.IndexFunc(b.categories, func(c types.Category) bool { return c.ID == id })
+	if i < 0 {
+		return notFoundError("category.delete", "category %s not found", id)
+	}
+	b.categories = slices.Delete(b.categories, i, i+1)
+	return nil
+}
+
+func validateDiscount(op string, d types.Discount) error {
+	switch d.Type {
+	case "", types.DiscountTypeDirect:
+		if d.Rate < 0 {
+			return validationError(op, "invalid discount: direct discount cannot be negative")
+		}
+	case types.DiscountTypePercentage:
+		if d.Rate < 0 || d.Rate > 100 {
+			return validationError(op, "invalid discount: percentage must be between 0 and 100")
+		}
+	default:
+		return validationError(op, "invalid discount: unknown type %q", d.Type)
+	}
+	return nil
+}
+
+func (b *MemoryBackend) CreateOrder(ctx context.Context, params types.OrderCreateParams) (*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(params.Items) == 0 {
+		return nil, validationError("order.create", "order has no items")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if i, ok := b.orderKeys[params.IdempotencyKey]; ok && params.IdempotencyKey != "" {
+		order := b.orders[i]
+		return &order, nil
+	}
+
+	order := types.Order{
+		ID:              fmt.Sprintf("mem-%06d", len(b.orders)+1),
+		TotalAmount:     params.Pricing.Total,
+		TotalDiscount:   params.Pricing.Discount,
+		ShippingDetails: params.ShippingAddress,
+		Status:          types.OrderStatus{Type: types.OrderStatusAccepted},
+	}
+	for _, item := range params.Items {
+		product := types.Product{ID: item.ProductID, Name: item.ProductName, SellingPrice: item.Price}
+		for _, p := range b.products {
+			if p.ID == item.ProductID {
+				product = p
+				break
+			}
+		}
+		order.OrderItems = append(order.OrderItems, types.OrderItem{Product: product, Quantity: item.Quantity})
+	}
+
+	b.orders = append(b.orders, order)
+	if params.IdempotencyKey != "" {
+		b.orderKeys[params.IdempotencyKey] = len(b.orders) - 1
+	}
+	return &order, nil
+}
+
+func (b *MemoryBackend) ListOrders(ctx context.Context, params types.OrderListParams) ([]types.Order, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return page(b.orders, params.Skip, params.Limit), len(b.orders), nil
+}
+
+func (b *MemoryBackend) GetOrder(ctx context.Context, id string) (*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, o := range b.orders {
+		if o.ID == id {
+			return &o, nil
+		}
+	}
+	return nil, notFoundError("order.get", "order %s not found", id)
+}
+
+func (b *MemoryBackend) UpdateOrderStatus(ctx context.Context, params types.OrderUpdateStatusParams) (*types.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !params.Status.Type.Valid() {
+		return nil, validationError("order.update_status", "unknown order status %q", params.Status.Type)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := slices.IndexFunc(b.orders, func(o types.Order) bool { return o.ID == params.ID })
+	if i < 0 {
+		return nil, notFoundError("order.update_status", "order %s not found", params.ID)
+	}
+	b.orders[i].Status = params.Status
+	updated := b.orders[i]
+	return &updated, nil
+}
+
+// newID returns a fresh identifier with the given prefix. Callers hold b.mu.
+func (b *MemoryBackend) newID(prefix string) string {
+	b.nextID++
+	return fmt.Sprintf("%s-mem-%04d", prefix, b.nextID)
+}
+
+func (b *MemoryBackend) withCategories(p types.Product, include bool) types.Product {
+	p.CategoryDetails = nil
+	if !include {
+		return p
+	}
+	for _, id := range p.Categories {
+		for _, c := range b.categories {
+			if c.ID == id {
+				p.CategoryDetails = append(p.CategoryDetails, types.CategoryDetail{
+					ID:          c.ID,
+					Name:        c.Name,
+					Description: c.Description,
+					Discount:    c.Discount,
+					Medias:      c.Medias,
+				})
+				break
+			}
+		}
+	}
+	return p
+}
+
+func matchesSearch(p types.Product, terms []string) bool {
+	haystack := strings.ToLower(strings.Join(append([]string{p.Name, p.Brand, p.ProductDescription}, p.Tags...), " "))
+	for _, term := range terms {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// page applies skip/take the way the backend does: a non-positive take
+// returns everything after skip.
+func page[T any](items []T, skip, take int) []T {
+	if skip < 0 {
+		skip = 0
+	}
+	if skip >= len(items) {
+		return []T{}
+	}
+	end := len(items)
+	if take > 0 && skip+take < end {
+		end = skip + take
+	}
+	return slices.Clone(items[skip:end])
+}
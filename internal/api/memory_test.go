@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"terminal-echoware/pkg/types"
+)
+
+func fixtureBackend(t *testing.T) *MemoryBackend {
+	t.Helper()
+	b, err := LoadMemoryBackend("../../fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestMemoryListProducts(t *testing.T) {
+	b := fixtureBackend(t)
+	ctx := context.Background()
+	all, total, err := b.ListProducts(ctx, types.ProductListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != total || total == 0 {
+		t.Fatalf("got %d products, total %d", len(all), total)
+	}
+
+	active := true
+	tests := []struct {
+		name   string
+		params types.ProductListParams
+		check  func([]types.Product, int) bool
+	}{
+		{
+			name:   "page",
+			params: types.ProductListParams{Skip: 1, Take: 2},
+			check: func(ps []types.Product, n int) bool {
+				return len(ps) == 2 && ps[0].ID == all[1].ID && n == total
+			},
+		},
+		{
+			name:   "skip past end",
+			params: types.ProductListParams{Skip: total + 1, Take: 2},
+			check:  func(ps []types.Product, n int) bool { return len(ps) == 0 && n == total },
+		},
+		{
+			name:   "active only",
+			params: types.ProductListParams{Active: &active},
+			check: func(ps []types.Product, _ int) bool {
+				for _, p := range ps {
+					if !p.Active {
+						return false
+					}
+				}
+				return len(ps) > 0
+			},
+		},
+		{
+			name:   "category",
+			params: types.ProductListParams{CategoryID: "cat-apparel", IncludeCategories: true},
+			check: func(ps []types.Product, _ int) bool {
+				for _, p := range ps {
+					if len(p.CategoryDetails) == 0 || p.CategoryDetails[0].ID != "cat-apparel" {
+						return false
+					}
+				}
+				return len(ps) > 0
+			},
+		},
+		{
+			name:   "no category details unless asked",
+			params: types.ProductListParams{CategoryID: "cat-apparel"},
+			check: func(ps []types.Product, _ int) bool {
+				return len(ps) > 0 && ps[0].CategoryDetails == nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, n, err := b.ListProducts(ctx, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(ps, n) {
+				t.Errorf("ListProducts(%+v) = %d products, total %d", tt.params, len(ps), n)
+			}
+		})
+	}
+}
+
+func TestMemorySearchProducts(t *testing.T) {
+	b := fixtureBackend(t)
+	tests := []struct {
+		query string
+		want  string // ID of the first match; "" for none
+	}{
+		{"classic tee", "prd-0001"},
+		{"TEE", "prd-0001"},
+		{"no such thing", ""},
+	}
+	for _, tt := range tests {
+		ps, _, err := b.SearchProducts(context.Background(), types.ProductSearchParams{SearchTerm: tt.query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if len(ps) > 0 {
+			got = ps[0].ID
+		}
+		if got != tt.want {
+			t.Errorf("search %q: first match %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryErrors(t *testing.T) {
+	b := fixtureBackend(t)
+	ctx := context.Background()
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"unknown product", func() error { _, err := b.GetProduct(ctx, "nope"); return err }, ErrNotFound},
+		{"empty order", func() error { _, err := b.CreateOrder(ctx, types.OrderCreateParams{}); return err }, ErrValidation},
+		{"bad discount", func() error {
+			_, err := b.CreateCategory(ctx, types.CategoryCreateParams{Name: "x", Discount: types.Discount{Rate: 120, Type: types.DiscountTypePercentage}})
+			return err
+		}, ErrValidation},
+		{"cancelled", func() error { _, _, err := b.ListProducts(cancelled, types.ProductListParams{}); return err }, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryCreateOrderIdempotent(t *testing.T) {
+	b := fixtureBackend(t)
+	params := types.OrderCreateParams{
+		Items:          []types.OrderItemInput{{ProductID: "prd-0001", Quantity: 1}},
+		Pricing:        types.OrderPricingInput{Total: types.NewMoney(79900, "")},
+		IdempotencyKey: "key-1",
+	}
+	first, err := b.CreateOrder(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := b.CreateOrder(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("retry created %s, want the original %s", again.ID, first.ID)
+	}
+	params.IdempotencyKey = "key-2"
+	other, err := b.CreateOrder(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.ID == first.ID {
+		t.Error("a new key returned the old order")
+	}
+	if first.OrderItems[0].Product.Name == "" {
+		t.Error("order item was not filled in from the catalog")
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"iter"
+	"terminal-echoware/pkg/types"
+)
+
+// DefaultPageSize is used by the paging iterators when params.Take is not set.
+const DefaultPageSize = 50
+
+type PageOptions struct {
+	// Prefetch requests the next page while the caller is still consuming
+	// the current one.
+	Prefetch bool
+}
+
+// ProductPages pages through product.list from params.Skip onwards, params.Take
+// items at a time. Paging ends at the response's Count when the backend sends
+// one, and otherwise at the first empty page. A failed page is yielded as its error; if the caller keeps
+// iterating, paging resumes with the page after it when the total is known,
+// and stops otherwise. Breaking out of the loop stops any prefetch in flight.
+func ProductPages(ctx context.Context, b Backend, params types.ProductListParams, opts PageOptions) iter.Seq2[[]types.Product, error] {
+	return pages(ctx, params.Skip, params.Take, opts, func(ctx context.Context, skip, take int) ([]types.Product, int, error) {
+		p := params
+		p.Skip, p.Take = skip, take
+		return b.ListProducts(ctx, p)
+	})
+}
+
+// Products yields every product product.list returns for params, one at a
+// time. See ProductPages for paging and error semantics.
+func Products(ctx context.Context, b Backend, params types.ProductListParams, opts PageOptions) iter.Seq2[types.Product, error] {
+	return flatten(ProductPages(ctx, b, params, opts))
+}
+
+// SearchPages pages through product.search like ProductPages.
+func SearchPages(ctx context.Context, b Backend, params types.ProductSearchParams, opts PageOptions) iter.Seq2[[]types.Product, error] {
+	return pages(ctx, params.Skip, params.Take, opts, func(ctx context.Context, skip, take int) ([]types.Product, int, error) {
+		p := params
+		p.Skip, p.Take = skip, take
+		return b.SearchProducts(ctx, p)
+	})
+}
+
+// SearchResults yields every product matching params, one at a time.
+func SearchResults(ctx context.Context, b Backend, params types.ProductSearchParams, opts PageOptions) iter.Seq2[types.Product, error] {
+	return flatten(SearchPages(ctx, b, params, opts))
+}
+
+type pageFetcher[T any] func(ctx context.Context, skip, take int) ([]T, int, error)
+
+type pageResult[T any] struct {
+	items []T
+	total int
+	err   error
+}
+
+func pages[T any](ctx context.Context, skip, size int, opts PageOptions, fetch pageFetcher[T]) iter.Seq2[[]T, error] {
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	if skip < 0 {
+		skip = 0
+	}
+	return func(yield func([]T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// The channel is buffered so an abandoned prefetch never blocks.
+		start := func(skip int, async bool) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+			run := func() {
+				items, total, err := fetch(ctx, skip, size)
+				ch <- pageResult[T]{items, total, err}
+			}
+			if async {
+				go run()
+			} else {
+				run()
+			}
+			return ch
+		}
+
+		total := -1
+		next := start(skip, false)
+		for {
+			res := <-next
+			var nextSkip int
+			var more bool
+			if res.err != nil {
+				// The failed page's size is unknown; resume after a full one.
+				nextSkip = skip + size
+				more = total >= 0 && nextSkip < total
+			} else {
+				// A backend may cap the page size, so a short page does not
+				// mean the end: continue after what arrived until an empty
+				// page or, when Count is sent, the total.
+				if res.total > 0 {
+					total = res.total
+				}
+				nextSkip = skip + len(res.items)
+				more = len(res.items) > 0 && (total < 0 || nextSkip < total)
+			}
+			if more && opts.Prefetch {
+				next = start(nextSkip, true)
+			}
+			if !yield(res.items, res.err) || !more || ctx.Err() != nil {
+				return
+			}
+			if !opts.Prefetch {
+				next = start(nextSkip, false)
+			}
+			skip = nextSkip
+		}
+	}
+}
+
+func flatten[T any](pages iter.Seq2[[]T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range pages {
+			if err != nil {
+				var zero T
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
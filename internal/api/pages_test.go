@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"terminal-echoware/pkg/types"
+)
+
+// fakePages serves n items, at most limit per call, and reports the total
+// when withTotal is set. failAt makes the call at that skip fail once.
+type fakePages struct {
+	mu        *sync.Mutex // set by start
+	n, limit  int
+	withTotal bool
+	failAt    int
+	failed    bool
+	calls     []int
+}
+
+func (f fakePages) start() *fakePages {
+	f.mu = new(sync.Mutex)
+	return &f
+}
+
+var errPage = errors.New("page failed")
+
+func (f *fakePages) fetch(_ context.Context, skip, take int) ([]int, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, skip)
+	if skip == f.failAt && !f.failed {
+		f.failed = true
+		return nil, 0, errPage
+	}
+	if f.limit > 0 && take > f.limit {
+		take = f.limit
+	}
+	var items []int
+	for i := skip; i < skip+take && i < f.n; i++ {
+		items = append(items, i)
+	}
+	total := 0
+	if f.withTotal {
+		total = f.n
+	}
+	return items, total, nil
+}
+
+func TestPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		fake      fakePages
+		size      int
+		want      []int
+		wantErrs  int
+		wantCalls []int
+	}{
+		{
+			name:      "total on exact pages",
+			fake:      fakePages{n: 10, withTotal: true, failAt: -1},
+			size:      5,
+			want:      seq(0, 10),
+			wantCalls: []int{0, 5},
+		},
+		{
+			name:      "total with short last page",
+			fake:      fakePages{n: 7, withTotal: true, failAt: -1},
+			size:      5,
+			want:      seq(0, 7),
+			wantCalls: []int{0, 5},
+		},
+		{
+			name:      "backend caps page size",
+			fake:      fakePages{n: 7, limit: 3, withTotal: true, failAt: -1},
+			size:      5,
+			want:      seq(0, 7),
+			wantCalls: []int{0, 3, 6},
+		},
+		{
+			name:      "no total ends on empty page",
+			fake:      fakePages{n: 7, limit: 3, failAt: -1},
+			size:      5,
+			want:      seq(0, 7),
+			wantCalls: []int{0, 3, 6, 7},
+		},
+		{
+			name:      "error with known total resumes",
+			fake:      fakePages{n: 15, withTotal: true, failAt: 5},
+			size:      5,
+			want:      append(seq(0, 5), seq(10, 15)...),
+			wantErrs:  1,
+			wantCalls: []int{0, 5, 10},
+		},
+		{
+			name:      "error with unknown total stops",
+			fake:      fakePages{n: 15, failAt: 5},
+			size:      5,
+			want:      seq(0, 5),
+			wantErrs:  1,
+			wantCalls: []int{0, 5},
+		},
+		{
+			name:      "empty catalog",
+			fake:      fakePages{failAt: -1},
+			size:      5,
+			wantCalls: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		for _, prefetch := range []bool{false, true} {
+			name := tt.name
+			if prefetch {
+				name += "/prefetch"
+			}
+			t.Run(name, func(t *testing.T) {
+				f := tt.fake.start()
+				var got []int
+				errs := 0
+				for page, err := range pages(context.Background(), 0, tt.size, PageOptions{Prefetch: prefetch}, f.fetch) {
+					if err != nil {
+						errs++
+						continue
+					}
+					got = append(got, page...)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("items = %v, want %v", got, tt.want)
+				}
+				if errs != tt.wantErrs {
+					t.Errorf("errors = %d, want %d", errs, tt.wantErrs)
+				}
+				if !slices.Equal(f.calls, tt.wantCalls) {
+					t.Errorf("fetched skips %v, want %v", f.calls, tt.wantCalls)
+				}
+			})
+		}
+	}
+}
+
+func TestPagesEarlyBreak(t *testing.T) {
+	f := fakePages{n: 100, withTotal: true, failAt: -1}.start()
+	var got []int
+	for item, err := range flatten(pages(context.Background(), 0, 10, PageOptions{}, f.fetch)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, item)
+		if item == 12 {
+			break
+		}
+	}
+	if !slices.Equal(got, seq(0, 13)) {
+		t.Errorf("items = %v", got)
+	}
+	if !slices.Equal(f.calls, []int{0, 10}) {
+		t.Errorf("fetched skips %v, want [0 10]", f.calls)
+	}
+}
+
+func TestProductPagesMemoryBackend(t *testing.T) {
+	var products []types.Product
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		products = append(products, types.Product{ID: id, Name: id, Active: true})
+	}
+	b := NewMemoryBackend(products, nil)
+	all, _, err := b.ListProducts(context.Background(), types.ProductListParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for p, err := range Products(context.Background(), b, types.ProductListParams{Take: 2}, PageOptions{Prefetch: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.ID != all[n].ID {
+			t.Errorf("product %d = %s, want %s", n, p.ID, all[n].ID)
+		}
+		n++
+	}
+	if n != len(all) {
+		t.Errorf("got %d products, want %d", n, len(all))
+	}
+}
+
+func seq(from, to int) []int {
+	var s []int
+	for i := from; i < to; i++ {
+		s = append(s, i)
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how CallAPI retries transient failures. Queries are
+// always eligible; mutations only when they carry an idempotency key.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// IdempotencyHeader carries the client-generated key for mutations so the
+// backend can deduplicate retried requests.
+const IdempotencyHeader = "Idempotency-Key"
+
+// backoff returns a full-jitter delay for the given zero-based attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// NewIdempotencyKey returns a random key suitable for IdempotencyHeader.
+func NewIdempotencyKey() string {
+	return cryptorand.Text()
+}
@@ -0,0 +1,192 @@
+// Package audit writes an append-only JSON-lines record of backend
+// operations. Files are rotated by size, old files are pruned by count and
+// age, and customer PII is masked before anything reaches disk.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	Path       string        // empty disables auditing
+	MaxSize    int64         // rotate once the file would exceed this many bytes; 0 never rotates
+	MaxBackups int           // rotated files to keep; 0 keeps none
+	MaxAge     time.Duration // delete rotated files older than this; 0 keeps them
+	Mode       os.FileMode   // permissions for the log and its rotated copies
+}
+
+// Entry is one audit record.
+type Entry struct {
+	Time       time.Time       `json:"time"`
+	SessionID  string          `json:"session_id,omitempty"`
+	Operation  string          `json:"operation"`
+	DurationMS int64           `json:"duration_ms"`
+	Status     string          `json:"status"` // "ok" or "error"
+	HTTPStatus int             `json:"http_status,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Params     json.RawMessage `json:"params,omitempty"` // already redacted
+}
+
+// Logger appends entries to the configured file. A nil *Logger discards
+// everything, so callers need not check whether auditing is enabled.
+type Logger struct {
+	cfg Config
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// Open creates the log directory and file if needed. It returns a nil
+// Logger when cfg.Path is empty.
+func Open(cfg Config) (*Logger, error) {
+	if cfg.Path == "" {
+		return nil, nil
+	}
+	if cfg.Mode == 0 {
+		cfg.Mode = 0o600
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o700); err != nil {
+		return nil, fmt.Errorf("create audit dir: %w", err)
+	}
+	l := &Logger{cfg: cfg}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Logger) open() error {
+	f, err := os.OpenFile(l.cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, l.cfg.Mode)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	// OpenFile only applies the mode on creation; tighten files left behind
+	// by older versions too.
+	if err := f.Chmod(l.cfg.Mode); err != nil {
+		f.Close()
+		return fmt.Errorf("chmod audit log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat audit log: %w", err)
+	}
+	l.f = f
+	l.size = info.Size()
+	return nil
+}
+
+// Log writes e as one JSON line. Failures are reported on stderr rather than
+// returned: auditing must never break a customer's request.
+func (l *Logger) Log(e Entry) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit: marshal entry: %v\n", err)
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	if l.cfg.MaxSize > 0 && l.size > 0 && l.size+int64(len(line)) > l.cfg.MaxSize {
+		if err := l.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "audit: rotate: %v\n", err)
+			if l.f == nil {
+				return
+			}
+		}
+	}
+	n, err := l.f.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit: write: %v\n", err)
+	}
+}
+
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// rotate shifts path.N to path.N+1, moves the live file to path.1 and opens
+// a fresh one. Callers hold l.mu.
+func (l *Logger) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	l.f = nil
+
+	path := l.cfg.Path
+	if l.cfg.MaxBackups <= 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return l.open()
+	}
+	os.Remove(backupName(path, l.cfg.MaxBackups))
+	for i := l.cfg.MaxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backupName(path, i), backupName(path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(path, backupName(path, 1)); err != nil {
+		return err
+	}
+	l.prune()
+	return l.open()
+}
+
+// prune removes rotated files older than MaxAge.
+func (l *Logger) prune() {
+	if l.cfg.MaxAge <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-l.cfg.MaxAge)
+	for i := 1; i <= l.cfg.MaxBackups; i++ {
+		name := backupName(l.cfg.Path, i)
+		if info, err := os.Stat(name); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(name)
+		}
+	}
+}
+
+func backupName(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+type sessionKey struct{}
+
+// WithSessionID tags ctx so entries logged for calls made with it carry id.
+func WithSessionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, id)
+}
+
+func SessionID(ctx context.Context) string {
+	id, _ := ctx.Value(sessionKey{}).(string)
+	return id
+}
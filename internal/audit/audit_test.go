@@ -0,0 +1,188 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readEntries returns the operations logged in path, in order.
+func readEntries(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var ops []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		ops = append(ops, e.Operation)
+	}
+	return ops
+}
+
+func TestOpenDisabled(t *testing.T) {
+	l, err := Open(Config{})
+	if err != nil || l != nil {
+		t.Fatalf("Open with no path = %v, %v", l, err)
+	}
+	l.Log(Entry{Operation: "product.list"})
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	l, err := Open(Config{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Log(Entry{Operation: "product.list", Status: "ok", Params: Redact(map[string]string{"email": "asha@example.com"})})
+	l.Log(Entry{Operation: "order.create", Status: "error"})
+	l.Close()
+	l.Log(Entry{Operation: "after close"})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var first Entry
+	if err := json.Unmarshal(data[:bytes.IndexByte(data, '\n')], &first); err != nil {
+		t.Fatal(err)
+	}
+	if first.Time.IsZero() || string(first.Params) != `{"email":"a***@example.com"}` {
+		t.Errorf("first entry = %+v", first)
+	}
+	if got := readEntries(t, path); len(got) != 2 || got[1] != "order.create" {
+		t.Errorf("logged %v", got)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("mode = %o, want 600", mode)
+	}
+}
+
+func TestOpenTightensMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := Open(Config{Path: path, Mode: 0o640})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	info, _ := os.Stat(path)
+	if mode := info.Mode().Perm(); mode != 0o640 {
+		t.Errorf("mode = %o, want 640", mode)
+	}
+	if l.size != 3 {
+		t.Errorf("size = %d, want the existing file's", l.size)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackups int
+		want       []string // entry in the live file, then in .1, .2, ...
+	}{
+		{"no backups", 0, []string{"op4"}},
+		{"one backup", 1, []string{"op4", "op3"}},
+		{"keeps the newest", 2, []string{"op4", "op3", "op2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			// Every entry is larger than MaxSize, so each one after the
+			// first starts a new file.
+			l, err := Open(Config{Path: path, MaxSize: 10, MaxBackups: tt.maxBackups})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, op := range []string{"op1", "op2", "op3", "op4"} {
+				l.Log(Entry{Operation: op})
+			}
+			l.Close()
+
+			for i, want := range tt.want {
+				name := path
+				if i > 0 {
+					name = backupName(path, i)
+				}
+				if got := readEntries(t, name); len(got) != 1 || got[0] != want {
+					t.Errorf("%s holds %v, want [%s]", filepath.Base(name), got, want)
+				}
+			}
+			if _, err := os.Stat(backupName(path, len(tt.want))); !os.IsNotExist(err) {
+				t.Errorf("more than %d backups kept", tt.maxBackups)
+			}
+		})
+	}
+}
+
+func TestRotateUnderSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(Config{Path: path, MaxSize: 1 << 20, MaxBackups: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 10 {
+		l.Log(Entry{Operation: "product.list"})
+	}
+	l.Close()
+	if got := readEntries(t, path); len(got) != 10 {
+		t.Errorf("live file holds %d entries, want 10", len(got))
+	}
+	if _, err := os.Stat(backupName(path, 1)); !os.IsNotExist(err) {
+		t.Error("rotated before reaching MaxSize")
+	}
+}
+
+func TestPruneByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(Config{Path: path, MaxSize: 10, MaxBackups: 3, MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.Log(Entry{Operation: "op1"})
+	l.Log(Entry{Operation: "op2"}) // op1 moves to .1
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(backupName(path, 1), old, old); err != nil {
+		t.Fatal(err)
+	}
+	l.Log(Entry{Operation: "op3"}) // op1 moves to .2 and is pruned
+
+	if _, err := os.Stat(backupName(path, 2)); !os.IsNotExist(err) {
+		t.Error("backup older than MaxAge was kept")
+	}
+	if got := readEntries(t, backupName(path, 1)); len(got) != 1 || got[0] != "op2" {
+		t.Errorf(".1 holds %v, want [op2]", got)
+	}
+}
+
+func TestSessionID(t *testing.T) {
+	ctx := context.Background()
+	if id := SessionID(ctx); id != "" {
+		t.Errorf("SessionID = %q without one set", id)
+	}
+	if id := SessionID(WithSessionID(ctx, "s-1")); id != "s-1" {
+		t.Errorf("SessionID = %q, want s-1", id)
+	}
+}
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// piiFields are the JSON keys whose values are masked, compared after
+// lower-casing and dropping underscores so "full_name" and "fullName" match.
+var piiFields = map[string]bool{
+	"fullname":     true,
+	"phone":        true,
+	"agentphone":   true,
+	"email":        true,
+	"useremail":    true,
+	"address":      true,
+	"addressline1": true,
+	"addressline2": true,
+	"postalcode":   true,
+	"clerktoken":   true,
+}
+
+// Redact marshals v and masks every PII field at any depth.
+func Redact(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil
+	}
+	out, err := json.Marshal(redactValue(tree))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if s, ok := child.(string); ok && isPII(k) {
+				v[k] = mask(s)
+				continue
+			}
+			v[k] = redactValue(child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+		return v
+	}
+	return v
+}
+
+func isPII(key string) bool {
+	return piiFields[strings.ReplaceAll(strings.ToLower(key), "_", "")]
+}
+
+// mask keeps just enough to correlate with a support ticket: the first
+// letter and domain of an email, or the last two characters of anything else.
+func mask(s string) string {
+	if s == "" {
+		return ""
+	}
+	if at := strings.LastIndex(s, "@"); at > 0 {
+		return s[:1] + "***" + s[at:]
+	}
+	r := []rune(s)
+	if len(r) <= 4 {
+		return "***"
+	}
+	return "***" + string(r[len(r)-2:])
+}
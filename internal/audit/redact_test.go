@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"asha@example.com", "a***@example.com"},
+		{"a.b@c@example.com", "a***@example.com"},
+		{"@example.com", "***om"},
+		{"9876543210", "***10"},
+		{"1234", "***"},
+		{"आशा शर्मा", "***मा"},
+	}
+	for _, tt := range tests {
+		if got := mask(tt.in); got != tt.want {
+			t.Errorf("mask(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	type address struct {
+		FullName   string `json:"fullName"`
+		PostalCode string `json:"postal_code"`
+		City       string `json:"city"`
+	}
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{
+			name: "camel and snake keys",
+			in:   address{FullName: "Asha Sharma", PostalCode: "411001", City: "Pune"},
+			want: `{"city":"Pune","fullName":"***ma","postal_code":"***01"}`,
+		},
+		{
+			name: "nested in lists",
+			in: map[string]any{
+				"orders": []any{map[string]any{"shipping": map[string]any{"Email": "asha@example.com"}}},
+			},
+			want: `{"orders":[{"shipping":{"Email":"a***@example.com"}}]}`,
+		},
+		{
+			name: "non-string pii is left alone",
+			in:   map[string]any{"phone": 12345678, "address": map[string]any{"city": "Pune"}},
+			want: `{"address":{"city":"Pune"},"phone":12345678}`,
+		},
+		{
+			name: "nothing to mask",
+			in:   []int{1, 2},
+			want: `[1,2]`,
+		},
+		{
+			name: "unmarshalable",
+			in:   func() {},
+			want: ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Redact(tt.in)
+			if string(got) != tt.want {
+				t.Errorf("Redact = %s, want %s", got, tt.want)
+			}
+			if len(got) > 0 && !json.Valid(got) {
+				t.Errorf("Redact produced invalid JSON")
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package i18n
+
+var en = map[string]Message{
+	// Shared
+	"back":           {Other: "← Back"},
+	"cart.badge":     {Other: "Cart(%d)"},
+	"total":          {Other: "Total"},
+	"shortcuts":      {Other: "KEYBOARD SHORTCUTS"},
+	"banner.offline": {Other: "⚠ Shop offline: browsing saved catalog, checkout disabled"},
+
+	// Loading
+	"loading.about":    {Other: "ABOUT US"},
+	"loading.cancel":   {Other: "%s to cancel"},
+	"loading.products": {Other: "Loading products..."},
+	"loading.product":  {Other: "Loading product..."},
+	"loading.search":   {Other: "Searching for '%s'..."},
+	"loading.order":    {Other: "Placing order..."},
+
+	// Home and search
+	"home.empty":    {Other: "No products available."},
+	"home.reload":   {Other: "Press %s to reload"},
+	"search.title":  {Other: "SEARCH"},
+	"search.prompt": {Other: "Search"},
+	"search.hint":   {Other: "Type to search • %s to execute • %s to select"},
+	"search.start":  {Other: "Start typing to search..."},
+	"search.none":   {Other: "No results found."},
+	"search.found":  {One: "Found %d result:", Other: "Found %d results:"},
+
+	// Product
+	"product.not_found":      {Other: "Product not found."},
+	"product.off":            {Other: "%.0f%% OFF"},
+	"product.description":    {Other: "DESCRIPTION"},
+	"product.no_description": {Other: "No description available."},
+	"product.features":       {Other: "FEATURES"},
+	"product.options":        {Other: "OPTIONS"},
+	"product.quantity":       {Other: "Quantity"},
+	"product.tags":           {Other: "TAGS"},
+
+	// Cart
+	"cart.title":  {Other: "SHOPPING CART"},
+	"cart.empty":  {Other: "Your cart is empty."},
+	"cart.browse": {Other: "Press %s to browse products"},
+
+	// Address
+	"address.title":       {Other: "SHIPPING DETAILS"},
+	"field.full_name":     {Other: "Full Name"},
+	"field.name":          {Other: "Name"},
+	"field.phone":         {Other: "Phone"},
+	"field.email":         {Other: "Email"},
+	"field.address":       {Other: "Address"},
+	"field.address_line1": {Other: "Address 1"},
+	"field.address_line2": {Other: "Address 2"},
+	"field.city":          {Other: "City"},
+	"field.state":         {Other: "State"},
+	"field.postal_code":   {Other: "Postal"},
+	"field.country":       {Other: "Country"},
+
+	"invalid.full_name":     {Other: "Full name is required"},
+	"invalid.phone":         {Other: "Enter a valid phone number"},
+	"invalid.email":         {Other: "Enter a valid email address"},
+	"invalid.address_line1": {Other: "Address line 1 is required"},
+	"invalid.city":          {Other: "City is required"},
+	"invalid.state":         {Other: "State is required"},
+	"invalid.postal_code":   {Other: "Enter a valid postal code"},
+	"invalid.country":       {Other: "Country is required"},
+
+	// Checkout and order
+	"checkout.title":    {Other: "CHECKOUT"},
+	"checkout.summary":  {Other: "ORDER SUMMARY"},
+	"checkout.qty":      {Other: "Qty: %d"},
+	"checkout.more":     {One: "... and %d more item", Other: "... and %d more items"},
+	"checkout.shipping": {Other: "SHIPPING ADDRESS"},
+	"checkout.confirm":  {Other: "%s to confirm"},
+	"order.placed":      {Other: "✓ ORDER PLACED SUCCESSFULLY!"},
+	"order.details":     {Other: "ORDER DETAILS"},
+	"order.id":          {Other: "Order ID"},
+	"order.status":      {Other: "Status"},
+	"order.thanks":      {Other: "Thank you for your order!"},
+
+	"status.accepted":         {Other: "Accepted"},
+	"status.rejected":         {Other: "Rejected"},
+	"status.rejected_by_user": {Other: "Cancelled"},
+	"status.delivered":        {Other: "Delivered"},
+	"status.out_for_delivery": {Other: "Out for delivery"},
+	"status.agent":            {Other: "Assigned to agent"},
+	"status.agent_changed":    {Other: "Agent changed"},
+	"status.in_hub":           {Other: "In hub"},
+
+	// Notifications
+	"notify.cancelled":        {Other: "Request cancelled"},
+	"notify.added":            {One: "Added %d item to cart!", Other: "Added %d items to cart!"},
+	"notify.added_variant":    {One: "Added %d item (%s) to cart!", Other: "Added %d items (%s) to cart!"},
+	"notify.max_quantity":     {Other: "Maximum quantity of %d reached for this item"},
+	"notify.quantity_up":      {Other: "Quantity increased"},
+	"notify.quantity_down":    {Other: "Quantity decreased"},
+	"notify.removed":          {Other: "Removed %s"},
+	"notify.checkout_offline": {Other: "Checkout is unavailable while the shop is offline"},
+	"notify.cart_restored":    {One: "Restored %d item from your last visit", Other: "Restored %d items from your last visit"},
+	"notify.cart_removed":     {One: "%d saved item is no longer available", Other: "%d saved items are no longer available"},
+	"notify.cart_repriced":    {One: "The price of %d saved item has changed", Other: "The prices of %d saved items have changed"},
+	"notify.cart_unverified":  {One: "%d saved item could not be checked; its price may have changed", Other: "%d saved items could not be checked; their prices may have changed"},
+	"notify.theme":            {Other: "Theme: %s"},
+	"notify.language":         {Other: "Language: %s"},
+
+	// Errors
+	"error.order_timeout":     {Other: "The shop took too long to confirm your order. Press Enter to try again; you will not be charged twice."},
+	"error.timeout":           {Other: "The shop took too long to respond. Please try again."},
+	"error.order_unavailable": {Other: "Your order could not be confirmed right now. Press Enter to try again; you will not be charged twice."},
+	"error.unavailable":       {Other: "The shop is temporarily unavailable. Please try again in a moment."},
+	"error.not_found":         {Other: "That item is no longer available."},
+	"error.validation_detail": {Other: "Please check your details: %s"},
+	"error.validation":        {Other: "Please check your details and try again."},
+	"error.unknown":           {Other: "Something went wrong: %s"},
+
+	// Accounts
+	"account.guest":     {Other: "Guest"},
+	"account.signed_in": {Other: "Signed in as %s"},
+	"orders.title":      {Other: "ORDER HISTORY"},
+	"orders.empty":      {Other: "No orders yet."},
+	"orders.guest":      {Other: "Sign in with an SSH key to keep your order history."},
+	"orders.items":      {One: "%d item", Other: "%d items"},
+	"address.saved":     {Other: "Saved address %d of %d"},
+
+	// Help labels
+	"help.up":                {Other: "Up"},
+	"help.down":              {Other: "Down"},
+	"help.view":              {Other: "View"},
+	"help.cart":              {Other: "Cart"},
+	"help.theme":             {Other: "Theme"},
+	"help.language":          {Other: "Language"},
+	"help.search":            {Other: "Search"},
+	"help.select":            {Other: "Select"},
+	"help.next_option":       {Other: "Next Option"},
+	"help.prev_option":       {Other: "Prev Option"},
+	"help.next_value":        {Other: "Next Value"},
+	"help.prev_value":        {Other: "Prev Value"},
+	"help.scroll_up":         {Other: "Scroll Up"},
+	"help.scroll_down":       {Other: "Scroll Down"},
+	"help.increase_quantity": {Other: "Increase Qty"},
+	"help.decrease_quantity": {Other: "Decrease Qty"},
+	"help.remove":            {Other: "Remove Item"},
+	"help.checkout":          {Other: "Checkout"},
+	"help.next":              {Other: "Next"},
+	"help.previous":          {Other: "Previous"},
+	"help.continue":          {Other: "Continue"},
+	"help.confirm":           {Other: "Confirm"},
+	"help.back":              {Other: "Back"},
+	"help.continue_shopping": {Other: "Continue Shopping"},
+	"help.orders":            {Other: "Orders"},
+	"help.saved_address":     {Other: "Saved Address"},
+
+	// Action descriptions, used when the config leaves one empty.
+	"action.navigate_up":   {Other: "Navigate up"},
+	"action.navigate_down": {Other: "Navigate down"},
+	"action.select":        {Other: "Select/Confirm"},
+	"action.search":        {Other: "Search"},
+	"action.cart":          {Other: "View Cart"},
+	"action.add_to_cart":   {Other: "Add to Cart"},
+	"action.delete":        {Other: "Delete"},
+	"action.back":          {Other: "Back"},
+	"action.quit":          {Other: "Quit"},
+	"action.tab":           {Other: "Switch field"},
+	"action.prev_field":    {Other: "Previous field"},
+	"action.option_left":   {Other: "Previous option"},
+	"action.option_right":  {Other: "Next option"},
+	"action.increase":      {Other: "Increase quantity"},
+	"action.decrease":      {Other: "Decrease quantity"},
+	"action.confirm":       {Other: "Place order"},
+	"action.cancel":        {Other: "Go back"},
+	"action.theme":         {Other: "Switch theme"},
+	"action.language":      {Other: "Switch language"},
+	"action.orders":        {Other: "Order history"},
+}
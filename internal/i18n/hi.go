@@ -0,0 +1,173 @@
+package i18n
+
+var hi = map[string]Message{
+	// Shared
+	"back":           {Other: "← वापस"},
+	"cart.badge":     {Other: "कार्ट(%d)"},
+	"total":          {Other: "कुल"},
+	"shortcuts":      {Other: "कीबोर्ड शॉर्टकट"},
+	"banner.offline": {Other: "⚠ दुकान ऑफ़लाइन है: सहेजा गया कैटलॉग दिख रहा है, चेकआउट बंद है"},
+
+	// Loading
+	"loading.about":    {Other: "हमारे बारे में"},
+	"loading.cancel":   {Other: "रद्द करने के लिए %s"},
+	"loading.products": {Other: "उत्पाद लोड हो रहे हैं..."},
+	"loading.product":  {Other: "उत्पाद लोड हो रहा है..."},
+	"loading.search":   {Other: "'%s' खोजा जा रहा है..."},
+	"loading.order":    {Other: "ऑर्डर दिया जा रहा है..."},
+
+	// Home and search
+	"home.empty":    {Other: "कोई उत्पाद उपलब्ध नहीं है।"},
+	"home.reload":   {Other: "फिर से लोड करने के लिए %s दबाएँ"},
+	"search.title":  {Other: "खोज"},
+	"search.prompt": {Other: "खोजें"},
+	"search.hint":   {Other: "खोजने के लिए टाइप करें • चलाने के लिए %s • चुनने के लिए %s"},
+	"search.start":  {Other: "खोजने के लिए टाइप करना शुरू करें..."},
+	"search.none":   {Other: "कोई परिणाम नहीं मिला।"},
+	"search.found":  {One: "%d परिणाम मिला:", Other: "%d परिणाम मिले:"},
+
+	// Product
+	"product.not_found":      {Other: "उत्पाद नहीं मिला।"},
+	"product.off":            {Other: "%.0f%% छूट"},
+	"product.description":    {Other: "विवरण"},
+	"product.no_description": {Other: "कोई विवरण उपलब्ध नहीं है।"},
+	"product.features":       {Other: "विशेषताएँ"},
+	"product.options":        {Other: "विकल्प"},
+	"product.quantity":       {Other: "मात्रा"},
+	"product.tags":           {Other: "टैग"},
+
+	// Cart
+	"cart.title":  {Other: "शॉपिंग कार्ट"},
+	"cart.empty":  {Other: "आपका कार्ट खाली है।"},
+	"cart.browse": {Other: "उत्पाद देखने के लिए %s दबाएँ"},
+
+	// Address
+	"address.title":       {Other: "शिपिंग विवरण"},
+	"field.full_name":     {Other: "पूरा नाम"},
+	"field.name":          {Other: "नाम"},
+	"field.phone":         {Other: "फ़ोन"},
+	"field.email":         {Other: "ईमेल"},
+	"field.address":       {Other: "पता"},
+	"field.address_line1": {Other: "पता 1"},
+	"field.address_line2": {Other: "पता 2"},
+	"field.city":          {Other: "शहर"},
+	"field.state":         {Other: "राज्य"},
+	"field.postal_code":   {Other: "पिन कोड"},
+	"field.country":       {Other: "देश"},
+
+	"invalid.full_name":     {Other: "पूरा नाम आवश्यक है"},
+	"invalid.phone":         {Other: "मान्य फ़ोन नंबर दर्ज करें"},
+	"invalid.email":         {Other: "मान्य ईमेल पता दर्ज करें"},
+	"invalid.address_line1": {Other: "पता पंक्ति 1 आवश्यक है"},
+	"invalid.city":          {Other: "शहर आवश्यक है"},
+	"invalid.state":         {Other: "राज्य आवश्यक है"},
+	"invalid.postal_code":   {Other: "मान्य पिन कोड दर्ज करें"},
+	"invalid.country":       {Other: "देश आवश्यक है"},
+
+	// Checkout and order
+	"checkout.title":    {Other: "चेकआउट"},
+	"checkout.summary":  {Other: "ऑर्डर सारांश"},
+	"checkout.qty":      {Other: "मात्रा: %d"},
+	"checkout.more":     {One: "... और %d आइटम", Other: "... और %d आइटम"},
+	"checkout.shipping": {Other: "शिपिंग पता"},
+	"checkout.confirm":  {Other: "पुष्टि के लिए %s"},
+	"order.placed":      {Other: "✓ ऑर्डर सफलतापूर्वक दिया गया!"},
+	"order.details":     {Other: "ऑर्डर विवरण"},
+	"order.id":          {Other: "ऑर्डर आईडी"},
+	"order.status":      {Other: "स्थिति"},
+	"order.thanks":      {Other: "आपके ऑर्डर के लिए धन्यवाद!"},
+
+	"status.accepted":         {Other: "स्वीकृत"},
+	"status.rejected":         {Other: "अस्वीकृत"},
+	"status.rejected_by_user": {Other: "रद्द किया गया"},
+	"status.delivered":        {Other: "डिलीवर हो गया"},
+	"status.out_for_delivery": {Other: "डिलीवरी के लिए निकला"},
+	"status.agent":            {Other: "एजेंट को सौंपा गया"},
+	"status.agent_changed":    {Other: "एजेंट बदला गया"},
+	"status.in_hub":           {Other: "हब में"},
+
+	// Notifications
+	"notify.cancelled":        {Other: "अनुरोध रद्द किया गया"},
+	"notify.added":            {One: "कार्ट में %d आइटम जोड़ा गया!", Other: "कार्ट में %d आइटम जोड़े गए!"},
+	"notify.added_variant":    {One: "कार्ट में %d आइटम (%s) जोड़ा गया!", Other: "कार्ट में %d आइटम (%s) जोड़े गए!"},
+	"notify.max_quantity":     {Other: "इस आइटम की अधिकतम मात्रा %d हो चुकी है"},
+	"notify.quantity_up":      {Other: "मात्रा बढ़ाई गई"},
+	"notify.quantity_down":    {Other: "मात्रा घटाई गई"},
+	"notify.removed":          {Other: "%s हटाया गया"},
+	"notify.checkout_offline": {Other: "दुकान ऑफ़लाइन होने पर चेकआउट उपलब्ध नहीं है"},
+	"notify.cart_restored":    {One: "पिछली बार का %d आइटम कार्ट में वापस जोड़ा गया", Other: "पिछली बार के %d आइटम कार्ट में वापस जोड़े गए"},
+	"notify.cart_removed":     {One: "सहेजा गया %d आइटम अब उपलब्ध नहीं है", Other: "सहेजे गए %d आइटम अब उपलब्ध नहीं हैं"},
+	"notify.cart_repriced":    {Other: "सहेजे गए %d आइटम की कीमत बदल गई है"},
+	"notify.cart_unverified":  {One: "सहेजे गए %d आइटम की जाँच नहीं हो सकी; इसकी कीमत बदली हो सकती है", Other: "सहेजे गए %d आइटम की जाँच नहीं हो सकी; इनकी कीमतें बदली हो सकती हैं"},
+	"notify.theme":            {Other: "थीम: %s"},
+	"notify.language":         {Other: "भाषा: %s"},
+
+	// Errors
+	"error.order_timeout":     {Other: "दुकान को आपका ऑर्डर पक्का करने में बहुत समय लगा। फिर से कोशिश करने के लिए Enter दबाएँ; आपसे दो बार शुल्क नहीं लिया जाएगा।"},
+	"error.timeout":           {Other: "दुकान ने जवाब देने में बहुत समय लिया। कृपया फिर से कोशिश करें।"},
+	"error.order_unavailable": {Other: "आपका ऑर्डर अभी पक्का नहीं हो सका। फिर से कोशिश करने के लिए Enter दबाएँ; आपसे दो बार शुल्क नहीं लिया जाएगा।"},
+	"error.unavailable":       {Other: "दुकान अभी अस्थायी रूप से उपलब्ध नहीं है। कृपया थोड़ी देर में फिर से कोशिश करें।"},
+	"error.not_found":         {Other: "यह आइटम अब उपलब्ध नहीं है।"},
+	"error.validation_detail": {Other: "कृपया अपना विवरण जाँचें: %s"},
+	"error.validation":        {Other: "कृपया अपना विवरण जाँचें और फिर से कोशिश करें।"},
+	"error.unknown":           {Other: "कुछ गड़बड़ हो गई: %s"},
+
+	// Accounts
+	"account.guest":     {Other: "अतिथि"},
+	"account.signed_in": {Other: "%s के रूप में साइन इन"},
+	"orders.title":      {Other: "ऑर्डर इतिहास"},
+	"orders.empty":      {Other: "अभी तक कोई ऑर्डर नहीं।"},
+	"orders.guest":      {Other: "अपना ऑर्डर इतिहास रखने के लिए SSH कुंजी से साइन इन करें।"},
+	"orders.items":      {One: "%d आइटम", Other: "%d आइटम"},
+	"address.saved":     {Other: "सहेजा गया पता %d / %d"},
+
+	// Help labels
+	"help.up":                {Other: "ऊपर"},
+	"help.down":              {Other: "नीचे"},
+	"help.view":              {Other: "देखें"},
+	"help.cart":              {Other: "कार्ट"},
+	"help.theme":             {Other: "थीम"},
+	"help.language":          {Other: "भाषा"},
+	"help.search":            {Other: "खोजें"},
+	"help.select":            {Other: "चुनें"},
+	"help.next_option":       {Other: "अगला विकल्प"},
+	"help.prev_option":       {Other: "पिछला विकल्प"},
+	"help.next_value":        {Other: "अगला मान"},
+	"help.prev_value":        {Other: "पिछला मान"},
+	"help.scroll_up":         {Other: "ऊपर स्क्रॉल"},
+	"help.scroll_down":       {Other: "नीचे स्क्रॉल"},
+	"help.increase_quantity": {Other: "मात्रा बढ़ाएँ"},
+	"help.decrease_quantity": {Other: "मात्रा घटाएँ"},
+	"help.remove":            {Other: "आइटम हटाएँ"},
+	"help.checkout":          {Other: "चेकआउट"},
+	"help.next":              {Other: "अगला"},
+	"help.previous":          {Other: "पिछला"},
+	"help.continue":          {Other: "जारी रखें"},
+	"help.confirm":           {Other: "पुष्टि करें"},
+	"help.back":              {Other: "वापस"},
+	"help.continue_shopping": {Other: "खरीदारी जारी रखें"},
+	"help.orders":            {Other: "ऑर्डर"},
+	"help.saved_address":     {Other: "सहेजा पता"},
+
+	// Action descriptions
+	"action.navigate_up":   {Other: "ऊपर जाएँ"},
+	"action.navigate_down": {Other: "नीचे जाएँ"},
+	"action.select":        {Other: "चुनें/पुष्टि करें"},
+	"action.search":        {Other: "खोजें"},
+	"action.cart":          {Other: "कार्ट देखें"},
+	"action.add_to_cart":   {Other: "कार्ट में डालें"},
+	"action.delete":        {Other: "हटाएँ"},
+	"action.back":          {Other: "वापस"},
+	"action.quit":          {Other: "बाहर निकलें"},
+	"action.tab":           {Other: "फ़ील्ड बदलें"},
+	"action.prev_field":    {Other: "पिछला फ़ील्ड"},
+	"action.option_left":   {Other: "पिछला विकल्प"},
+	"action.option_right":  {Other: "अगला विकल्प"},
+	"action.increase":      {Other: "मात्रा बढ़ाएँ"},
+	"action.decrease":      {Other: "मात्रा घटाएँ"},
+	"action.confirm":       {Other: "ऑर्डर दें"},
+	"action.cancel":        {Other: "वापस जाएँ"},
+	"action.theme":         {Other: "थीम बदलें"},
+	"action.language":      {Other: "भाषा बदलें"},
+	"action.orders":        {Other: "ऑर्डर इतिहास"},
+}
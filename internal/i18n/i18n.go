@@ -0,0 +1,106 @@
+// Package i18n holds the TUI's message catalogs. Messages are looked up by
+// ID in the session's language and fall back to English, then to the ID
+// itself, so a missing translation never blanks out the screen.
+package i18n
+
+import (
+	"fmt"
+
+	"terminal-echoware/pkg/config"
+)
+
+// Default is the language used when none is set or the requested one has
+// no catalog.
+const Default = "en"
+
+// Message is one catalog entry. Plain messages only set Other; plural ones
+// set One as well and are formatted with Printer.Plural.
+type Message struct {
+	One   string
+	Other string
+}
+
+type catalog struct {
+	name    string // the language's own name for itself
+	plural  func(n int) bool
+	entries map[string]Message
+}
+
+// catalogs has an entry for each of config.Languages.
+var catalogs = map[string]*catalog{
+	"en": {name: "English", plural: oneIsSingular, entries: en},
+	"hi": {name: "हिन्दी", plural: zeroOrOneIsSingular, entries: hi},
+}
+
+func oneIsSingular(n int) bool { return n == 1 }
+
+// Hindi uses the singular for 0 and 1 (CLDR "one": i = 0 or n = 1).
+func zeroOrOneIsSingular(n int) bool { return n == 0 || n == 1 }
+
+// Name returns a language's name in that language, e.g. "हिन्दी" for "hi".
+func Name(lang string) string {
+	if c, ok := catalogs[lang]; ok {
+		return c.name
+	}
+	return lang
+}
+
+// Printer formats messages in one language.
+type Printer struct {
+	lang string
+	cat  *catalog
+}
+
+// New returns a printer for lang, or for Default if lang has no catalog.
+func New(lang string) *Printer {
+	lang, ok := config.MatchLanguage(lang)
+	if !ok {
+		lang = Default
+	}
+	return &Printer{lang: lang, cat: catalogs[lang]}
+}
+
+// Language returns the language p prints in.
+func (p *Printer) Language() string {
+	return p.lang
+}
+
+// Has reports whether p's own catalog, not the fallback, has id.
+func (p *Printer) Has(id string) bool {
+	_, ok := p.cat.entries[id]
+	return ok
+}
+
+// T formats the message id with args.
+func (p *Printer) T(id string, args ...any) string {
+	return format(p.lookup(id).Other, args)
+}
+
+// Plural formats the message id for a count of n, picking the singular or
+// plural form by the language's rules. n is the first format argument,
+// followed by args.
+func (p *Printer) Plural(id string, n int, args ...any) string {
+	msg := p.lookup(id)
+	form := msg.Other
+	if msg.One != "" && p.cat.plural(n) {
+		form = msg.One
+	}
+	return format(form, append([]any{n}, args...))
+}
+
+func (p *Printer) lookup(id string) Message {
+	if msg, ok := p.cat.entries[id]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[Default].entries[id]; ok {
+		return msg
+	}
+	return Message{Other: id}
+}
+
+func format(s string, args []any) string {
+	if len(args) == 0 {
+		return s
+	}
+	return fmt.Sprintf(s, args...)
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"testing"
+
+	"terminal-echoware/pkg/config"
+)
+
+// Every language the config accepts needs a catalog, and a catalog for a
+// language the config rejects could never be selected.
+func TestCatalogsMatchConfig(t *testing.T) {
+	for _, lang := range config.Languages {
+		if _, ok := catalogs[lang]; !ok {
+			t.Errorf("no catalog for %q", lang)
+		}
+	}
+	if len(catalogs) != len(config.Languages) {
+		t.Errorf("%d catalogs for %d languages %v", len(catalogs), len(config.Languages), config.Languages)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		lang string
+		id   string
+		n    int
+		want string
+	}{
+		{"en", "orders.items", 0, "0 items"},
+		{"en", "orders.items", 1, "1 item"},
+		{"en", "orders.items", 2, "2 items"},
+		{"en", "search.found", 0, "Found 0 results:"},
+		// Hindi uses the singular for 0 as well as 1.
+		{"hi", "search.found", 0, "0 परिणाम मिला:"},
+		{"hi", "search.found", 1, "1 परिणाम मिला:"},
+		{"hi", "search.found", 2, "2 परिणाम मिले:"},
+	}
+	for _, tt := range tests {
+		if got := New(tt.lang).Plural(tt.id, tt.n); got != tt.want {
+			t.Errorf("%s Plural(%s, %d) = %q, want %q", tt.lang, tt.id, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFallback(t *testing.T) {
+	p := New("xx")
+	if p.Language() != Default {
+		t.Fatalf("New(xx).Language() = %q, want %q", p.Language(), Default)
+	}
+	if got := p.T("no.such.message"); got != "no.such.message" {
+		t.Errorf("T(missing) = %q, want the ID", got)
+	}
+}
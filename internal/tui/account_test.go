@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"testing"
+
+	"terminal-echoware/internal/account"
+	"terminal-echoware/pkg/types"
+)
+
+func TestSavedAddressCycling(t *testing.T) {
+	accounts, err := account.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := account.Identity{User: "asha", Fingerprint: "SHA256:test"}
+	home := types.ShippingDetails{FullName: "Asha", AddressLine1: "Home"}
+	work := types.ShippingDetails{FullName: "Asha", AddressLine1: "Work"}
+	for _, a := range []types.ShippingDetails{home, work} {
+		accounts.SaveAddress(id, a)
+	}
+
+	m := loaded(t, accounts, id)
+	checkout(t, m, "")
+	first := m.address
+	if first.AddressLine1 != "Work" {
+		t.Fatalf("form starts with %q, want the most recent address", first.AddressLine1)
+	}
+	m.Update(press("right"))
+	if m.address.AddressLine1 != "Home" {
+		t.Fatalf("→ shows %q, want the next saved address", m.address.AddressLine1)
+	}
+	m.Update(press("right"))
+	if m.address != first {
+		t.Fatalf("→ did not wrap around")
+	}
+
+	m.Update(press("!"))
+	edited := m.address
+	m.Update(press("right"))
+	m.Update(press("left"))
+	if m.address != edited {
+		t.Errorf("←/→ replaced an edited form: %+v", m.address)
+	}
+}
+
+func TestOrderIsRememberedPerStorefront(t *testing.T) {
+	accounts, err := account.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := account.Identity{User: "asha", Fingerprint: "SHA256:test"}
+
+	m := loaded(t, accounts, id)
+	m.overlay.Storefront = "outlet"
+	checkout(t, m, "1 MG Road")
+	m.Update(press("enter"))
+	_, cmd := m.Update(press("y"))
+	run(m, cmd)
+	if m.screen != types.ScreenOrderSuccess {
+		t.Fatalf("screen = %v, want order success", m.screen)
+	}
+
+	c, _ := accounts.Customer(id.Fingerprint)
+	if len(c.Orders) != 1 || c.Orders[0].Shop != "outlet" {
+		t.Fatalf("orders = %+v, want one from outlet", c.Orders)
+	}
+	if len(c.Addresses) != 1 || c.Addresses[0].AddressLine1 != "1 MG Road" {
+		t.Errorf("addresses = %+v", c.Addresses)
+	}
+	if cart := accounts.Cart(id.Fingerprint, "outlet"); len(cart) != 0 {
+		t.Errorf("ordered cart was kept: %+v", cart)
+	}
+}
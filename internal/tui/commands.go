@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"terminal-echoware/internal/api"
 	"terminal-echoware/pkg/types"
 
@@ -8,62 +10,109 @@ import (
 )
 
 type productsLoadedMsg struct {
+	seq      int
 	products []types.Product
 	count    int
 	err      error
 }
 
 type productLoadedMsg struct {
+	seq     int
 	product *types.Product
 	err     error
 }
 
 type searchResultsMsg struct {
+	seq      int
 	products []types.Product
 	count    int
 	err      error
 }
 
 type orderCreatedMsg struct {
+	seq   int
 	order *types.Order
 	err   error
 }
 
-func loadProductsCmd(client *api.Client, skip, take int) tea.Cmd {
+type cartRestoredMsg struct {
+	items      []types.CartItem
+	removed    int // items no longer for sale
+	repriced   int // items whose price changed while they were saved
+	unverified int // items kept as saved because the shop could not be asked
+}
+
+func loadProductsCmd(req request, backend api.Backend, skip, take int) tea.Cmd {
 	return func() tea.Msg {
 		active := true
-		products, count, err := client.ListProducts(types.ProductListParams{
+		products, count, err := backend.ListProducts(req.ctx, types.ProductListParams{
 			Skip:              skip,
 			Take:              take,
 			Active:            &active,
 			IncludeCategories: true,
 		})
-		return productsLoadedMsg{products: products, count: count, err: err}
+		return productsLoadedMsg{seq: req.seq, products: products, count: count, err: err}
 	}
 }
 
-func loadProductCmd(client *api.Client, id string) tea.Cmd {
+func loadProductCmd(req request, backend api.Backend, id string) tea.Cmd {
 	return func() tea.Msg {
-		product, err := client.GetProduct(id)
-		return productLoadedMsg{product: product, err: err}
+		product, err := backend.GetProduct(req.ctx, id)
+		return productLoadedMsg{seq: req.seq, product: product, err: err}
 	}
 }
 
-func searchProductsCmd(client *api.Client, query string, skip, take int) tea.Cmd {
+func searchProductsCmd(req request, backend api.Backend, query string, skip, take int) tea.Cmd {
 	return func() tea.Msg {
-		products, count, err := client.SearchProducts(types.ProductSearchParams{
+		products, count, err := backend.SearchProducts(req.ctx, types.ProductSearchParams{
 			SearchTerm:        query,
 			Skip:              skip,
 			Take:              take,
 			IncludeCategories: true,
 		})
-		return searchResultsMsg{products: products, count: count, err: err}
+		return searchResultsMsg{seq: req.seq, products: products, count: count, err: err}
+	}
+}
+
+func createOrderCmd(req request, backend api.Backend, params types.OrderCreateParams) tea.Cmd {
+	return func() tea.Msg {
+		order, err := backend.CreateOrder(req.ctx, params)
+		return orderCreatedMsg{seq: req.seq, order: order, err: err}
 	}
 }
 
-func createOrderCmd(client *api.Client, params types.OrderCreateParams) tea.Cmd {
+// restoreCartCmd checks a saved cart against the shop itself, bypassing the
+// catalog cache so neither cached nor stale prices pass as current. Items
+// whose product or variant is no longer for sale are dropped and the rest
+// pick up the current product details and price. An item that cannot be
+// checked right now, say because the shop is down, is kept as saved and
+// counted as unverified.
+func restoreCartCmd(ctx context.Context, backend api.Backend, saved []types.CartItem) tea.Cmd {
+	backend = api.Uncached(backend)
 	return func() tea.Msg {
-		order, err := client.CreateOrder(params)
-		return orderCreatedMsg{order: order, err: err}
+		var msg cartRestoredMsg
+		for _, item := range saved {
+			product, err := backend.GetProduct(ctx, item.Product.ID)
+			switch {
+			case errors.Is(err, api.ErrNotFound):
+				msg.removed++
+				continue
+			case err != nil:
+				msg.items = append(msg.items, item)
+				msg.unverified++
+				continue
+			case !product.Available(item.Variant):
+				msg.removed++
+				continue
+			}
+			was, now := item.Product.SellingPrice, product.SellingPrice
+			if was.Code() != now.Code() || was.Minor != now.Minor {
+				msg.repriced++
+			}
+			item.Product = *product
+			item.Quantity = min(item.Quantity, types.MaxCartQuantity)
+			msg.items = append(msg.items, item)
+		}
+		return msg
 	}
 }
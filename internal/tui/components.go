@@ -3,10 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
-	"terminal-echoware/pkg/config"
 	"terminal-echoware/pkg/types"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // Column widths for alignment
@@ -18,10 +18,10 @@ const (
 	ColQty     = 12
 )
 
-func RenderProductLine(product types.Product, selected bool, width int) string {
-	style := ProductCardStyle
+func (s *Styles) RenderProductLine(product types.Product, selected bool, width int) string {
+	style := s.ProductCard
 	if selected {
-		style = ProductCardSelectedStyle
+		style = s.ProductCardSelected
 	}
 
 	cursor := "  "
@@ -40,31 +40,31 @@ func RenderProductLine(product types.Product, selected bool, width int) string {
 
 	name := padRight(truncate(product.Name, nameWidth), nameWidth)
 	brand := padRight(truncate(product.Brand, ColBrand-2), ColBrand-2)
-	price := fmt.Sprintf("₹%.0f", product.SellingPrice)
+	price := s.FormatPrice(product.SellingPrice)
 
 	line := fmt.Sprintf("%s%s  %s  %s", 
 		cursor,
-		NormalStyle.Render(name),
-		BrandStyle.Render(brand),
-		PriceStyle.Render(price),
+		s.Normal.Render(name),
+		s.Brand.Render(brand),
+		s.Price.Render(price),
 	)
 	return style.Width(width).Render(line)
 }
 
-func RenderProductList(products []types.Product, cursor int, width int) string {
+func (s *Styles) RenderProductList(products []types.Product, cursor int, width int) string {
 	var b strings.Builder
 	for i, product := range products {
-		b.WriteString(RenderProductLine(product, i == cursor, width))
+		b.WriteString(s.RenderProductLine(product, i == cursor, width))
 		b.WriteString("\n")
 	}
 	return b.String()
 }
 
-func RenderCartItem(item types.CartItem, selected bool, width int) string {
-	style := NormalStyle
+func (s *Styles) RenderCartItem(item types.CartItem, selected bool, width int) string {
+	style := s.Normal
 	cursor := "  "
 	if selected {
-		style = SelectedStyle
+		style = s.Selected
 		cursor = "▸ "
 	}
 	
@@ -73,20 +73,20 @@ func RenderCartItem(item types.CartItem, selected bool, width int) string {
 		nameWidth = 15
 	}
 	
-	total := item.Product.SellingPrice * float64(item.Quantity)
+	total := item.Total()
 	name := padRight(truncate(item.Product.Name, nameWidth), nameWidth)
 	qty := fmt.Sprintf("x%d", item.Quantity)
-	price := fmt.Sprintf("₹%.0f", total)
+	price := s.FormatPrice(total)
 	
 	line := fmt.Sprintf("%s%s  %s  %s", cursor, name, padLeft(qty, 4), padLeft(price, 10))
 	return style.Width(width).Render(line)
 }
 
-func RenderCartItemWithQty(item types.CartItem, selected bool, width int) string {
-	style := NormalStyle
+func (s *Styles) RenderCartItemWithQty(item types.CartItem, selected bool, width int) string {
+	style := s.Normal
 	cursor := "  "
 	if selected {
-		style = SelectedStyle
+		style = s.Selected
 		cursor = "▸ "
 	}
 	
@@ -95,54 +95,56 @@ func RenderCartItemWithQty(item types.CartItem, selected bool, width int) string
 		nameWidth = 15
 	}
 	
-	total := item.Product.SellingPrice * float64(item.Quantity)
+	total := item.Total()
 	name := padRight(truncate(item.Product.Name, nameWidth), nameWidth)
 	
-	qtyStyle := HelpStyle
+	qtyStyle := s.Help
 	if selected {
-		qtyStyle = SuccessStyle
+		qtyStyle = s.Success
 	}
 	qtyStr := qtyStyle.Render(fmt.Sprintf("[ - ] %2d [ + ]", item.Quantity))
-	price := PriceStyle.Render(fmt.Sprintf("₹%.0f", total))
+	price := s.Price.Render(s.FormatPrice(total))
 	
 	line := fmt.Sprintf("%s%s  %s  %s", cursor, name, qtyStr, price)
 	return style.Width(width).Render(line)
 }
 
-func RenderCartList(items []types.CartItem, cursor int, width int) string {
+func (s *Styles) RenderCartList(items []types.CartItem, cursor int, width int) string {
 	var b strings.Builder
 	for i, item := range items {
-		b.WriteString(RenderCartItem(item, i == cursor, width))
+		b.WriteString(s.RenderCartItem(item, i == cursor, width))
 		b.WriteString("\n")
 	}
 	return b.String()
 }
 
-func RenderCartListWithQty(items []types.CartItem, cursor int, width int) string {
+func (s *Styles) RenderCartListWithQty(items []types.CartItem, cursor int, width int) string {
 	var b strings.Builder
 	for i, item := range items {
-		b.WriteString(RenderCartItemWithQty(item, i == cursor, width))
+		b.WriteString(s.RenderCartItemWithQty(item, i == cursor, width))
 		b.WriteString("\n")
 	}
 	return b.String()
 }
 
-func RenderHelp(screenName string, width int) string {
-	cfg := config.GetConfig()
-	if !cfg.ShowControls {
-		return ""
-	}
-	return FooterStyle.Width(width).Render(HelpStyle.Render(cfg.GetHelpText(screenName)))
+func (s *Styles) RenderHelp(helpText string, width int) string {
+	return s.Footer.Width(width).Render(s.Help.Render(helpText))
 }
 
-func RenderPrice(amount float64) string {
-	return PriceStyle.Render(fmt.Sprintf("₹%.0f", amount))
+// FormatPrice is the single place prices are turned into text; see
+// config.CurrencyConfig.
+func (s *Styles) FormatPrice(amount types.Money) string {
+	return s.Currency.Format(amount)
 }
 
-func RenderInputField(label, value string, focused bool, width int) string {
-	style := InputStyle.Width(width - 4)
+func (s *Styles) RenderPrice(amount types.Money) string {
+	return s.Price.Render(s.FormatPrice(amount))
+}
+
+func (s *Styles) RenderInputField(label, value string, focused bool, width int) string {
+	style := s.Input.Width(width - 4)
 	if focused {
-		style = InputFocusedStyle.Width(width - 4)
+		style = s.InputFocused.Width(width - 4)
 	}
 	
 	cursor := ""
@@ -154,18 +156,18 @@ func RenderInputField(label, value string, focused bool, width int) string {
 	return style.Render(content)
 }
 
-func RenderOrderItem(item types.OrderItem, width int) string {
+func (s *Styles) RenderOrderItem(item types.OrderItem, width int) string {
 	nameWidth := width - 20
 	if nameWidth < 15 {
 		nameWidth = 15
 	}
 	
-	total := item.Product.SellingPrice * float64(item.Quantity)
+	total := item.Product.SellingPrice.Mul(item.Quantity)
 	name := padRight(truncate(item.Product.Name, nameWidth), nameWidth)
-	return fmt.Sprintf("  %s  x%d  %s", name, item.Quantity, RenderPrice(total))
+	return fmt.Sprintf("  %s  x%d  %s", name, item.Quantity, s.RenderPrice(total))
 }
 
-func RenderNotification(notif *Notification) string {
+func (s *Styles) RenderNotification(notif *Notification) string {
 	if notif == nil {
 		return ""
 	}
@@ -173,21 +175,21 @@ func RenderNotification(notif *Notification) string {
 	var style lipgloss.Style
 	switch notif.Type {
 	case "success":
-		style = NotificationSuccessStyle
+		style = s.NotificationSuccess
 	case "error":
-		style = NotificationErrorStyle
+		style = s.NotificationError
 	default:
-		style = NotificationInfoStyle
+		style = s.NotificationInfo
 	}
 	
 	return style.Render(" " + notif.Message + " ")
 }
 
-func RenderHeader(title string, cartCount int, width int) string {
-	titleStr := TitleStyle.Render(title)
+func (s *Styles) RenderHeader(title string, cartCount int, width int) string {
+	titleStr := s.Title.Render(title)
 	
 	if cartCount > 0 {
-		badge := CartBadgeStyle.Render(fmt.Sprintf(" 🛒 %d ", cartCount))
+		badge := s.CartBadge.Render(fmt.Sprintf(" 🛒 %d ", cartCount))
 		// Calculate spacing
 		titleLen := lipgloss.Width(titleStr)
 		badgeLen := lipgloss.Width(badge)
@@ -201,45 +203,45 @@ func RenderHeader(title string, cartCount int, width int) string {
 	return titleStr
 }
 
-func RenderDivider(width int) string {
-	return DividerStyle.Render(strings.Repeat("─", width))
+func (s *Styles) RenderDivider(width int) string {
+	return s.Divider.Render(strings.Repeat("─", width))
 }
 
-func RenderQuantitySelector(quantity int, focused bool) string {
-	style := HelpStyle
+func (s *Styles) RenderQuantitySelector(quantity int, focused bool) string {
+	style := s.Help
 	if focused {
-		style = SuccessStyle
+		style = s.Success
 	}
 	return style.Render(fmt.Sprintf("[ - ]  %d  [ + ]", quantity))
 }
 
 // RenderOptionRow renders a single option row (like quantity)
-func RenderOptionRow(label string, value string, focused bool, width int) string {
-	rowStyle := OptionRowStyle.Width(width)
+func (s *Styles) RenderOptionRow(label string, value string, focused bool, width int) string {
+	rowStyle := s.OptionRow.Width(width)
 	if focused {
-		rowStyle = OptionRowFocusedStyle.Width(width)
+		rowStyle = s.OptionRowFocused.Width(width)
 	}
 	
-	labelStr := OptionLabelStyle.Render(padRight(label+":", 12))
+	labelStr := s.OptionLabel.Render(padRight(label+":", 12))
 	
 	var valueStr string
 	if focused {
-		valueStr = fmt.Sprintf("◀  %s  ▶", OptionValueSelectedStyle.Render(value))
+		valueStr = fmt.Sprintf("◀  %s  ▶", s.OptionValueSelected.Render(value))
 	} else {
-		valueStr = fmt.Sprintf("   %s   ", OptionValueStyle.Render(value))
+		valueStr = fmt.Sprintf("   %s   ", s.OptionValue.Render(value))
 	}
 	
 	return rowStyle.Render(fmt.Sprintf("  %s %s", labelStr, valueStr))
 }
 
 // RenderVariantRow renders a variant row with multiple options
-func RenderVariantRow(variantName string, options []string, selectedIdx int, focused bool, width int) string {
-	rowStyle := OptionRowStyle.Width(width)
+func (s *Styles) RenderVariantRow(variantName string, options []string, selectedIdx int, focused bool, width int) string {
+	rowStyle := s.OptionRow.Width(width)
 	if focused {
-		rowStyle = OptionRowFocusedStyle.Width(width)
+		rowStyle = s.OptionRowFocused.Width(width)
 	}
 	
-	labelStr := OptionLabelStyle.Render(padRight(variantName+":", 12))
+	labelStr := s.OptionLabel.Render(padRight(variantName+":", 12))
 	
 	var optionsStr strings.Builder
 	if focused {
@@ -250,9 +252,9 @@ func RenderVariantRow(variantName string, options []string, selectedIdx int, foc
 	
 	for i, opt := range options {
 		if i == selectedIdx {
-			optionsStr.WriteString(OptionValueSelectedStyle.Render(opt))
+			optionsStr.WriteString(s.OptionValueSelected.Render(opt))
 		} else {
-			optionsStr.WriteString(OptionValueUnselectedStyle.Render(opt))
+			optionsStr.WriteString(s.OptionValueUnselected.Render(opt))
 		}
 		if i < len(options)-1 {
 			optionsStr.WriteString(" ")
@@ -266,26 +268,31 @@ func RenderVariantRow(variantName string, options []string, selectedIdx int, foc
 	return rowStyle.Render(fmt.Sprintf("  %s %s", labelStr, optionsStr.String()))
 }
 
+// truncate, padRight and padLeft measure display cells rather than bytes,
+// so wide (CJK) and combining (Devanagari) text lines up with ASCII.
+
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if ansi.StringWidth(s) <= maxLen {
 		return s
 	}
 	if maxLen <= 3 {
-		return s[:maxLen]
+		return ansi.Truncate(s, maxLen, "")
 	}
-	return s[:maxLen-3] + "..."
+	return ansi.Truncate(s, maxLen, "...")
 }
 
 func padRight(s string, length int) string {
-	if len(s) >= length {
+	w := ansi.StringWidth(s)
+	if w >= length {
 		return s
 	}
-	return s + strings.Repeat(" ", length-len(s))
+	return s + strings.Repeat(" ", length-w)
 }
 
 func padLeft(s string, length int) string {
-	if len(s) >= length {
+	w := ansi.StringWidth(s)
+	if w >= length {
 		return s
 	}
-	return strings.Repeat(" ", length-len(s)) + s
+	return strings.Repeat(" ", length-w) + s
 }
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"terminal-echoware/internal/api"
+	"terminal-echoware/internal/i18n"
+)
+
+// friendlyError turns a backend failure into something a customer can act
+// on, in the session's language. Unclassified errors fall back to their raw
+// text.
+func friendlyError(tr *i18n.Printer, err error) string {
+	var apiErr *api.APIError
+	isOrder := errors.As(err, &apiErr) && apiErr.Op == "order.create"
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		if isOrder {
+			return tr.T("error.order_timeout")
+		}
+		return tr.T("error.timeout")
+	case errors.Is(err, api.ErrUnavailable):
+		if isOrder {
+			return tr.T("error.order_unavailable")
+		}
+		return tr.T("error.unavailable")
+	case errors.Is(err, api.ErrNotFound):
+		return tr.T("error.not_found")
+	case errors.Is(err, api.ErrValidation):
+		if apiErr != nil && apiErr.Message != "" {
+			return tr.T("error.validation_detail", apiErr.Message)
+		}
+		return tr.T("error.validation")
+	}
+	return tr.T("error.unknown", err.Error())
+}
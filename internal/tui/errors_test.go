@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"terminal-echoware/internal/api"
+	"terminal-echoware/internal/i18n"
+)
+
+func TestFriendlyError(t *testing.T) {
+	tr := i18n.New("en")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"not found", &api.APIError{Op: "product.get", StatusCode: http.StatusNotFound}, tr.T("error.not_found")},
+		{"200 not found", &api.APIError{Op: "product.get", StatusCode: http.StatusOK, Message: "not found"}, tr.T("error.not_found")},
+		{"validation with detail", &api.APIError{Op: "order.create", StatusCode: http.StatusUnprocessableEntity, Message: "phone is invalid"}, tr.T("error.validation_detail", "phone is invalid")},
+		{"validation", &api.APIError{Op: "order.create", StatusCode: http.StatusConflict}, tr.T("error.validation")},
+		{"unavailable", &api.APIError{Op: "product.list", StatusCode: http.StatusBadGateway}, tr.T("error.unavailable")},
+		{"rate limited", &api.APIError{Op: "product.list", StatusCode: http.StatusTooManyRequests}, tr.T("error.unavailable")},
+		{"transport", &api.APIError{Op: "product.list", Err: errors.New("connection refused")}, tr.T("error.unavailable")},
+		{"circuit open", &api.APIError{Op: "product.list", Err: api.ErrCircuitOpen}, tr.T("error.unavailable")},
+		{"order unavailable", &api.APIError{Op: "order.create", StatusCode: http.StatusServiceUnavailable}, tr.T("error.order_unavailable")},
+		{"circuit open at checkout", &api.APIError{Op: "order.create", Err: api.ErrCircuitOpen}, tr.T("error.order_unavailable")},
+		{"timeout", &api.APIError{Op: "product.get", Err: context.DeadlineExceeded}, tr.T("error.timeout")},
+		{"order timeout", &api.APIError{Op: "order.create", Err: context.DeadlineExceeded}, tr.T("error.order_timeout")},
+		{"unclassified", errors.New("boom"), tr.T("error.unknown", "boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := friendlyError(tr, tt.err); got != tt.want {
+				t.Errorf("friendlyError(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
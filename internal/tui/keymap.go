@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"terminal-echoware/internal/i18n"
+	"terminal-echoware/pkg/config"
+	"terminal-echoware/pkg/types"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds one key.Binding per action, built from
+// config.ControlsConfig. Handlers dispatch on these instead of raw keys, and
+// footers and shortcut sidebars are rendered from the same bindings.
+type KeyMap struct {
+	Up          key.Binding
+	Down        key.Binding
+	Select      key.Binding
+	Search      key.Binding
+	Cart        key.Binding
+	AddToCart   key.Binding
+	Delete      key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+	NextField   key.Binding
+	PrevField   key.Binding
+	OptionLeft  key.Binding
+	OptionRight key.Binding
+	Increase    key.Binding
+	Decrease    key.Binding
+	Confirm     key.Binding
+	Cancel      key.Binding
+	Theme       key.Binding
+	Language    key.Binding
+	Orders      key.Binding
+}
+
+func (k *KeyMap) binding(action string) *key.Binding {
+	switch action {
+	case config.ActionNavigateUp:
+		return &k.Up
+	case config.ActionNavigateDown:
+		return &k.Down
+	case config.ActionSelect:
+		return &k.Select
+	case config.ActionSearch:
+		return &k.Search
+	case config.ActionCart:
+		return &k.Cart
+	case config.ActionAddToCart:
+		return &k.AddToCart
+	case config.ActionDelete:
+		return &k.Delete
+	case config.ActionBack:
+		return &k.Back
+	case config.ActionQuit:
+		return &k.Quit
+	case config.ActionNextField:
+		return &k.NextField
+	case config.ActionPrevField:
+		return &k.PrevField
+	case config.ActionOptionLeft:
+		return &k.OptionLeft
+	case config.ActionOptionRight:
+		return &k.OptionRight
+	case config.ActionIncrease:
+		return &k.Increase
+	case config.ActionDecrease:
+		return &k.Decrease
+	case config.ActionConfirm:
+		return &k.Confirm
+	case config.ActionCancel:
+		return &k.Cancel
+	case config.ActionTheme:
+		return &k.Theme
+	case config.ActionLanguage:
+		return &k.Language
+	case config.ActionOrders:
+		return &k.Orders
+	}
+	return nil
+}
+
+// NewKeyMap builds the key map from c. Actions missing from c keep no keys
+// and never match; CustomBindings add keys on top of KeyBindings.
+// Descriptions in the config are English; other languages use tr's names
+// for the actions.
+func NewKeyMap(c config.ControlsConfig, tr *i18n.Printer) KeyMap {
+	var km KeyMap
+	bindings := make(map[string]config.KeyBinding, len(c.KeyBindings))
+	for action, b := range c.KeyBindings {
+		b.Keys = slices.Clone(b.Keys)
+		bindings[action] = b
+	}
+	for _, k := range slices.Sorted(maps.Keys(c.CustomBindings)) {
+		action := c.CustomBindings[k]
+		b := bindings[action]
+		b.Keys = append(b.Keys, k)
+		bindings[action] = b
+	}
+
+	for _, action := range config.Actions {
+		b := bindings[action]
+		desc := b.Description
+		if desc == "" || tr.Language() != i18n.Default {
+			desc = tr.T("action." + action)
+		}
+		kb := km.binding(action)
+		*kb = key.NewBinding(key.WithKeys(b.Keys...), key.WithHelp(b.Help(), desc))
+		if len(b.Keys) == 0 {
+			kb.SetEnabled(false)
+		}
+	}
+	return km
+}
+
+// textInput reports whether the current screen takes typed text, in which
+// case printable keys and space go to the input rather than to bindings.
+func (m *Model) textInput() bool {
+	return m.screen == types.ScreenSearch || m.screen == types.ScreenAddress
+}
+
+// matches reports whether msg triggers any of bindings on the current screen.
+func (m *Model) matches(msg tea.KeyMsg, bindings ...key.Binding) bool {
+	if m.textInput() && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) {
+		return false
+	}
+	return key.Matches(msg, bindings...)
+}
+
+// helpItem is one entry of a screen's help: a binding and, optionally, the
+// message ID of a label that replaces its description on that screen.
+type helpItem struct {
+	binding key.Binding
+	label   string
+}
+
+func (m *Model) helpDesc(h helpItem) string {
+	if h.label != "" {
+		return m.tr.T(h.label)
+	}
+	return h.binding.Help().Desc
+}
+
+// screenHelp lists the bindings shown for a screen, in display order.
+func (m *Model) screenHelp(screen types.Screen) []helpItem {
+	k := m.keys
+	switch screen {
+	case types.ScreenHome:
+		return []helpItem{
+			{k.Up, "help.up"}, {k.Down, "help.down"}, {k.Select, "help.view"}, {k.Search, ""},
+			{k.Cart, "help.cart"}, {k.Orders, "help.orders"}, {k.Theme, "help.theme"}, {k.Language, "help.language"}, {k.Quit, ""},
+		}
+	case types.ScreenSearch:
+		return []helpItem{{k.Up, "help.up"}, {k.Down, "help.down"}, {k.NextField, "help.search"}, {k.Select, "help.select"}, {k.Back, ""}}
+	case types.ScreenProduct:
+		return []helpItem{
+			{k.NextField, "help.next_option"}, {k.PrevField, "help.prev_option"},
+			{k.OptionLeft, "help.prev_value"}, {k.OptionRight, "help.next_value"},
+			{k.Up, "help.scroll_up"}, {k.Down, "help.scroll_down"},
+			{k.AddToCart, ""}, {k.Cart, ""}, {k.Back, ""}, {k.Quit, ""},
+		}
+	case types.ScreenCart:
+		return []helpItem{
+			{k.Up, ""}, {k.Down, ""}, {k.Increase, "help.increase_quantity"}, {k.Decrease, "help.decrease_quantity"},
+			{k.Delete, "help.remove"}, {k.Select, "help.checkout"}, {k.Back, ""}, {k.Quit, ""},
+		}
+	case types.ScreenAddress:
+		items := []helpItem{{k.NextField, "help.next"}, {k.PrevField, "help.previous"}, {k.Select, "help.continue"}, {k.Back, ""}}
+		if m.cyclingSavedAddresses() {
+			items = append(items, helpItem{k.OptionRight, "help.saved_address"})
+		}
+		return items
+	case types.ScreenCheckout:
+		return []helpItem{{k.Confirm, "help.confirm"}, {k.Select, "help.confirm"}, {k.Cancel, "help.back"}, {k.Back, ""}}
+	case types.ScreenOrderSuccess:
+		return []helpItem{{k.Select, "help.continue_shopping"}}
+	case types.ScreenOrders:
+		return []helpItem{{k.Up, "help.up"}, {k.Down, "help.down"}, {k.Back, ""}, {k.Quit, ""}}
+	}
+	return []helpItem{{k.Quit, ""}}
+}
+
+// showHelp reports whether the config wants the help line drawn at all.
+func (m *Model) showHelp() bool {
+	return m.cfg.ShowControls && m.cfg.Controls.ShowHelp
+}
+
+// helpLine renders a screen's help as a single line, or "" when help is
+// turned off.
+func (m *Model) helpLine(screen types.Screen) string {
+	if !m.showHelp() {
+		return ""
+	}
+	var parts []string
+	for _, h := range m.screenHelp(screen) {
+		if !h.binding.Enabled() {
+			continue
+		}
+		parts = append(parts, h.binding.Help().Key+" "+m.helpDesc(h))
+	}
+	return strings.Join(parts, "   ")
+}
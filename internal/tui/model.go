@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"context"
+	"slices"
 	"time"
+	"terminal-echoware/internal/account"
 	"terminal-echoware/internal/api"
+	"terminal-echoware/internal/i18n"
 	"terminal-echoware/pkg/config"
 	"terminal-echoware/pkg/types"
 
@@ -25,9 +29,13 @@ type VariantSelection struct {
 }
 
 type Model struct {
+	ctx               context.Context // session context, cancelled on disconnect
+	cancelRequest     context.CancelFunc
+	request           int // sequence ID of the backend call in flight; 0 if none
+	requests          int // sequence IDs handed out so far
 	screen            types.Screen
 	previousScreen    types.Screen
-	apiClient         *api.Client
+	backend           api.Backend
 	cart              types.Cart
 	homeProducts      []types.Product
 	searchResults     []types.Product
@@ -44,18 +52,34 @@ type Model struct {
 	loadingFrame      int
 	address           types.ShippingDetails
 	order             *types.Order
+	checkoutKey       string // idempotency key of the pending order.create
+	checkoutDigest    string // digest of the order the key was minted for
 	width             int
 	height            int
 	notification      *Notification
 	viewport          viewport.Model
 	viewportReady     bool
+	store             *config.Store
+	baseCfg           *config.AppConfig // snapshot of store, never modified
+	overlay           config.Overlay    // this session's own settings
+	cfg               *config.AppConfig // baseCfg with overlay applied
+	styles            *Styles
+	keys              KeyMap
+	tr                *i18n.Printer
+	accounts          *account.Store
+	identity          account.Identity
+	addressIndex      int // saved address shown on the address screen
 }
 
-func NewModel(apiClient *api.Client) *Model {
-	config.InitConfig()
-	return &Model{
+// NewModel creates a session on top of the store's current config. overlay
+// carries the session's own theme, language and key bindings; the shared
+// config is never modified. identity says whose session it is; guests get
+// nothing saved in accounts.
+func NewModel(ctx context.Context, backend api.Backend, store *config.Store, overlay config.Overlay, accounts *account.Store, identity account.Identity) *Model {
+	m := &Model{
+		ctx:               ctx,
 		screen:            types.ScreenHome,
-		apiClient:         apiClient,
+		backend:           backend,
 		cart:              types.Cart{Items: []types.CartItem{}},
 		cursor:            0,
 		productQuantity:   1,
@@ -64,17 +88,36 @@ func NewModel(apiClient *api.Client) *Model {
 		width:             80,
 		height:            24,
 		viewportReady:     false,
+		store:             store,
+		baseCfg:           store.Current(),
+		overlay:           overlay,
+		accounts:          accounts,
+		identity:          identity,
 	}
+	m.applyConfig()
+	return m
 }
 
+// applyConfig rebuilds the session's effective config, messages, styles and
+// key map from baseCfg and overlay.
+func (m *Model) applyConfig() {
+	m.cfg = m.baseCfg.WithOverlay(m.overlay)
+	m.tr = i18n.New(m.cfg.Language)
+	m.styles = NewStyles(m.cfg.Theme, m.cfg.Currency)
+	m.keys = NewKeyMap(m.cfg.Controls, m.tr)
+}
+
+// after schedules a message; tests replace it so timers never block them.
+var after = tea.Tick
+
 func tickCmd() tea.Cmd {
-	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
+	return after(100*time.Millisecond, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
 func clearNotificationCmd() tea.Cmd {
-	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+	return after(3*time.Second, func(t time.Time) tea.Msg {
 		return notificationClearMsg{}
 	})
 }
@@ -89,6 +132,148 @@ func (m *Model) SetLoading(loading bool, msg string) tea.Cmd {
 	return nil
 }
 
+// request identifies one backend call. Its reply carries seq back so that
+// replies to cancelled or superseded calls can be told apart and dropped.
+type request struct {
+	ctx context.Context
+	seq int
+}
+
+// newRequest starts the next backend call. Its context is derived from the
+// session context, and it replaces (and cancels) any request still in
+// flight, so at most one call per session is outstanding.
+func (m *Model) newRequest() request {
+	m.CancelRequest()
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancelRequest = cancel
+	m.requests++
+	m.request = m.requests
+	return request{ctx: ctx, seq: m.request}
+}
+
+// finishRequest ends the call seq and stops the spinner. It reports false,
+// leaving everything alone, when seq is not the call in flight: the reply is
+// late, for a call that was cancelled or replaced by a newer one.
+func (m *Model) finishRequest(seq int) bool {
+	if seq == 0 || seq != m.request {
+		return false
+	}
+	m.CancelRequest()
+	m.SetLoading(false, "")
+	return true
+}
+
+// CancelRequest aborts the in-flight backend call, if any.
+func (m *Model) CancelRequest() {
+	if m.cancelRequest != nil {
+		m.cancelRequest()
+		m.cancelRequest = nil
+	}
+	m.request = 0
+}
+
+// Degraded reports whether the backend is down and the session is limited
+// to browsing the cached catalog.
+func (m *Model) Degraded() bool {
+	return api.IsDegraded(m.backend)
+}
+
+// refreshConfig adopts a reloaded config, unless the reload policy says
+// sessions keep the snapshot they started with.
+func (m *Model) refreshConfig() {
+	cur := m.store.Current()
+	if cur == m.baseCfg || cur.Reload.Sessions == config.SessionsSnapshot {
+		return
+	}
+	m.baseCfg = cur
+	m.applyConfig()
+}
+
+// CycleTheme switches this session to the next built-in theme. The
+// configured theme keeps its color overrides when it comes round again.
+func (m *Model) CycleTheme() tea.Cmd {
+	next := config.ThemeNames[0]
+	if i := slices.Index(config.ThemeNames, m.styles.Theme.Name); i >= 0 {
+		next = config.ThemeNames[(i+1)%len(config.ThemeNames)]
+	}
+	m.overlay.Theme = &config.ThemeConfig{Name: next}
+	if m.baseCfg.Storefront(m.overlay.Storefront).Theme.Resolve().Name == next {
+		m.overlay.Theme = nil
+	}
+	m.applyConfig()
+	return m.SetNotification(m.tr.T("notify.theme", next), "info")
+}
+
+// CycleLanguage switches this session to the next language with a catalog.
+func (m *Model) CycleLanguage() tea.Cmd {
+	next := config.Languages[0]
+	if i := slices.Index(config.Languages, m.tr.Language()); i >= 0 {
+		next = config.Languages[(i+1)%len(config.Languages)]
+	}
+	m.overlay.Language = next
+	m.applyConfig()
+	return m.SetNotification(m.tr.T("notify.language", i18n.Name(next)), "info")
+}
+
+// Identity returns who the session belongs to.
+func (m *Model) Identity() account.Identity {
+	return m.identity
+}
+
+// customer returns the signed-in customer's record; guests have none.
+func (m *Model) customer() (account.Customer, bool) {
+	if m.identity.Guest() {
+		return account.Customer{}, false
+	}
+	return m.accounts.Customer(m.identity.Fingerprint)
+}
+
+// savedAddresses returns the customer's addresses, most recently used first.
+func (m *Model) savedAddresses() []types.ShippingDetails {
+	c, _ := m.customer()
+	return c.Addresses
+}
+
+// cyclingSavedAddresses reports whether ←/→ on the address screen step
+// through saved addresses: there must be more than one, and the form must
+// still hold the one shown, so nothing the customer typed is overwritten.
+func (m *Model) cyclingSavedAddresses() bool {
+	saved := m.savedAddresses()
+	if len(saved) < 2 {
+		return false
+	}
+	return m.address == (types.ShippingDetails{}) ||
+		m.addressIndex < len(saved) && m.address == saved[m.addressIndex]
+}
+
+// useSavedAddress fills the address form with saved address i, wrapping
+// around the list.
+func (m *Model) useSavedAddress(i int) {
+	saved := m.savedAddresses()
+	if len(saved) == 0 {
+		return
+	}
+	m.addressIndex = (i%len(saved) + len(saved)) % len(saved)
+	m.address = saved[m.addressIndex]
+}
+
+// savedCart returns the cart the customer left at this storefront last time.
+func (m *Model) savedCart() []types.CartItem {
+	if m.identity.Guest() {
+		return nil
+	}
+	return m.accounts.Cart(m.identity.Fingerprint, m.overlay.Storefront)
+}
+
+// saveCart stores the cart so it survives a disconnect. Guests' carts last
+// only as long as the session. The store writes it out in the background.
+func (m *Model) saveCart() {
+	if m.identity.Guest() {
+		return
+	}
+	m.accounts.SaveCart(m.identity, m.overlay.Storefront, m.cart.Items)
+}
+
 func (m *Model) SetError(err error) {
 	m.err = err
 	m.loading = false
@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"terminal-echoware/internal/account"
+	"terminal-echoware/internal/api"
+	"terminal-echoware/pkg/types"
+)
+
+// flakyBackend fails GetProduct for the IDs in down as if the shop were
+// unreachable.
+type flakyBackend struct {
+	*api.MemoryBackend
+	down map[string]bool
+}
+
+func (b flakyBackend) GetProduct(ctx context.Context, id string) (*types.Product, error) {
+	if b.down[id] {
+		return nil, fmt.Errorf("get %s: %w", id, api.ErrUnavailable)
+	}
+	return b.MemoryBackend.GetProduct(ctx, id)
+}
+
+func TestRestoreCart(t *testing.T) {
+	sizes := []types.ProductVariant{{VariantName: "Size", VariantValues: []types.ProductVariantValue{
+		{Label: "M", Active: true}, {Label: "XL", Active: false},
+	}}}
+	shop := []types.Product{
+		{ID: "tee", Name: "Tee", Active: true, SellingPrice: types.NewMoney(79900, ""), ProductVariants: sizes},
+		{ID: "mug", Name: "Mug", Active: true, SellingPrice: types.NewMoney(39900, "")}, // was 349.50
+		{ID: "old", Name: "Old", Active: false, SellingPrice: types.NewMoney(100, "")},
+		{ID: "cap", Name: "Cap", Active: true, SellingPrice: types.NewMoney(29900, "")},
+	}
+	saved := func(id string, price int64, qty int, variant map[string]string) types.CartItem {
+		return types.CartItem{Product: types.Product{ID: id, Name: id, SellingPrice: types.NewMoney(price, "")}, Quantity: qty, Variant: variant}
+	}
+
+	tests := []struct {
+		name     string
+		saved    []types.CartItem
+		down     []string
+		inCart   []types.CartItem // added before the restore lands
+		wantCart map[string]int   // product ID to quantity
+		wantMsg  []string         // notification parts, in English
+	}{
+		{
+			name:     "unchanged",
+			saved:    []types.CartItem{saved("tee", 79900, 2, map[string]string{"Size": "M"})},
+			wantCart: map[string]int{"tee": 2},
+			wantMsg:  []string{"Restored 2 items"},
+		},
+		{
+			name:     "not found",
+			saved:    []types.CartItem{saved("gone", 100, 1, nil), saved("cap", 29900, 1, nil)},
+			wantCart: map[string]int{"cap": 1},
+			wantMsg:  []string{"Restored 1 item", "1 saved item is no longer available"},
+		},
+		{
+			name:     "inactive product",
+			saved:    []types.CartItem{saved("old", 100, 1, nil)},
+			wantCart: map[string]int{},
+			wantMsg:  []string{"1 saved item is no longer available"},
+		},
+		{
+			name: "inactive or unknown variant",
+			saved: []types.CartItem{
+				saved("tee", 79900, 1, map[string]string{"Size": "XL"}),
+				saved("tee", 79900, 1, map[string]string{"Colour": "Red"}),
+			},
+			wantCart: map[string]int{},
+			wantMsg:  []string{"2 saved items are no longer available"},
+		},
+		{
+			name:     "repriced",
+			saved:    []types.CartItem{saved("mug", 34950, 1, nil)},
+			wantCart: map[string]int{"mug": 1},
+			wantMsg:  []string{"Restored 1 item", "The price of 1 saved item has changed"},
+		},
+		{
+			name:     "unverified",
+			saved:    []types.CartItem{saved("cap", 29900, 1, nil)},
+			down:     []string{"cap"},
+			wantCart: map[string]int{"cap": 1},
+			wantMsg:  []string{"Restored 1 item", "1 saved item could not be checked"},
+		},
+		{
+			name:     "merged into the cart",
+			saved:    []types.CartItem{saved("cap", 29900, 4, nil), saved("mug", 39900, 1, nil)},
+			inCart:   []types.CartItem{{Product: shop[3], Quantity: 2}},
+			wantCart: map[string]int{"cap": types.MaxCartQuantity, "mug": 1},
+			wantMsg:  []string{"Restored 5 items"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts, _ := account.Open("")
+			id := account.Identity{User: "asha", Fingerprint: "SHA256:asha"}
+			accounts.SaveCart(id, "", tt.saved)
+
+			backend := flakyBackend{MemoryBackend: api.NewMemoryBackend(shop, nil), down: map[string]bool{}}
+			for _, p := range tt.down {
+				backend.down[p] = true
+			}
+			m := newTestModelOn(t, backend, accounts, id)
+			for _, item := range tt.inCart {
+				m.cart.Add(item.Product, item.Quantity, item.Variant)
+			}
+			run(m, m.Init())
+
+			got := map[string]int{}
+			for _, item := range m.cart.Items {
+				got[item.Product.ID] += item.Quantity
+				if item.Product.ID == "mug" && item.Product.SellingPrice.Minor != 39900 {
+					t.Errorf("mug kept its saved price %v", item.Product.SellingPrice)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantCart) {
+				t.Errorf("cart = %v, want %v", got, tt.wantCart)
+			}
+
+			var msg string
+			if m.notification != nil {
+				msg = m.notification.Message
+			}
+			parts := strings.Split(msg, " • ")
+			if len(parts) != len(tt.wantMsg) || msg == "" {
+				t.Fatalf("notification %q, want %d parts %q", msg, len(tt.wantMsg), tt.wantMsg)
+			}
+			for i, want := range tt.wantMsg {
+				if !strings.HasPrefix(parts[i], want) {
+					t.Errorf("notification part %d = %q, want %q", i, parts[i], want)
+				}
+			}
+
+			// What was restored is saved again, so the next visit sees it.
+			if saved := accounts.Cart(id.Fingerprint, ""); len(saved) != len(m.cart.Items) {
+				t.Errorf("saved cart has %d lines, session %d", len(saved), len(m.cart.Items))
+			}
+		})
+	}
+}
+
+func TestRestoreCartBypassesCache(t *testing.T) {
+	mem := api.NewMemoryBackend([]types.Product{{ID: "cap", Active: true, SellingPrice: types.NewMoney(29900, "")}}, nil)
+	cache := api.NewCachedBackend(mem, api.DefaultCacheConfig)
+	if _, err := cache.GetProduct(context.Background(), "cap"); err != nil {
+		t.Fatal(err)
+	}
+	mem.UpdateProduct(context.Background(), types.ProductUpdateParams{ID: "cap", Active: new(bool)})
+
+	accounts, _ := account.Open("")
+	id := account.Identity{Fingerprint: "SHA256:asha"}
+	accounts.SaveCart(id, "", []types.CartItem{{Product: types.Product{ID: "cap"}, Quantity: 1}})
+	m := newTestModelOn(t, cache, accounts, id)
+	run(m, m.Init())
+	if len(m.cart.Items) != 0 {
+		t.Errorf("restored a product the shop has deactivated, from the cache")
+	}
+}
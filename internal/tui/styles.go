@@ -1,166 +1,238 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
-
-var (
-	// Colors
-	ColorPrimary   = lipgloss.Color("#FF79C6")
-	ColorSecondary = lipgloss.Color("#8BE9FD")
-	ColorAccent    = lipgloss.Color("#50FA7B")
-	ColorWarning   = lipgloss.Color("#FFB86C")
-	ColorError     = lipgloss.Color("#FF5555")
-	ColorMuted     = lipgloss.Color("#6272A4")
-	ColorBg        = lipgloss.Color("#282A36")
-	ColorBgLight   = lipgloss.Color("#44475A")
-	ColorFg        = lipgloss.Color("#F8F8F2")
+import (
+	"terminal-echoware/pkg/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Colors is the resolved palette a Styles was built from.
+type Colors struct {
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Accent    lipgloss.Color
+	Warning   lipgloss.Color
+	Error     lipgloss.Color
+	Success   lipgloss.Color
+	Muted     lipgloss.Color
+	Bg        lipgloss.Color
+	BgLight   lipgloss.Color
+	Fg        lipgloss.Color
+}
+
+// Styles is the full style set for one theme. Each session owns one, so
+// switching theme in one session leaves the others alone.
+type Styles struct {
+	Theme  config.ThemeConfig
+	Colors Colors
+	// Currency formats prices in FormatPrice and the Render helpers.
+	Currency config.CurrencyConfig
 
 	// Base styles
-	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			MarginBottom(1)
+	Title    lipgloss.Style
+	Subtitle lipgloss.Style
+	Error    lipgloss.Style
+	Loading  lipgloss.Style
+	Selected lipgloss.Style
+	Normal   lipgloss.Style
+	Price    lipgloss.Style
+	Brand    lipgloss.Style
+	Help     lipgloss.Style
+	Success  lipgloss.Style
 
-	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary).
-			MarginBottom(1)
+	// Box styles
+	Box       lipgloss.Style
+	HeaderBox lipgloss.Style
 
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorError).
-			Bold(true).
-			Padding(0, 1)
+	// Notification styles
+	NotificationSuccess lipgloss.Style
+	NotificationError   lipgloss.Style
+	NotificationInfo    lipgloss.Style
 
-	LoadingStyle = lipgloss.NewStyle().
-			Foreground(ColorWarning).
-			Bold(true)
+	// Degraded-mode banner
+	Banner lipgloss.Style
 
-	SelectedStyle = lipgloss.NewStyle().
-			Background(ColorBgLight).
-			Foreground(ColorFg).
-			Bold(true).
-			Padding(0, 1)
+	// Input styles
+	Input        lipgloss.Style
+	InputFocused lipgloss.Style
 
-	NormalStyle = lipgloss.NewStyle().
-			Foreground(ColorFg).
-			Padding(0, 1)
+	// Footer style
+	Footer lipgloss.Style
 
-	PriceStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+	// Divider style
+	Divider lipgloss.Style
 
-	BrandStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary)
+	// Product card style
+	ProductCard         lipgloss.Style
+	ProductCardSelected lipgloss.Style
+
+	// Badge style
+	Badge     lipgloss.Style
+	CartBadge lipgloss.Style
 
-	HelpStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			MarginTop(1)
+	// Option row styles
+	OptionLabel           lipgloss.Style
+	OptionValue           lipgloss.Style
+	OptionValueSelected   lipgloss.Style
+	OptionValueUnselected lipgloss.Style
+	OptionRowFocused      lipgloss.Style
+	OptionRow             lipgloss.Style
+}
+
+// NewStyles builds the style set for theme, filling unset colors from the
+// built-in theme it names, with prices formatted per currency.
+func NewStyles(theme config.ThemeConfig, currency config.CurrencyConfig) *Styles {
+	theme = theme.Resolve()
+	c := Colors{
+		Primary:   lipgloss.Color(theme.PrimaryColor),
+		Secondary: lipgloss.Color(theme.SecondaryColor),
+		Accent:    lipgloss.Color(theme.AccentColor),
+		Warning:   lipgloss.Color(theme.WarningColor),
+		Error:     lipgloss.Color(theme.ErrorColor),
+		Success:   lipgloss.Color(theme.SuccessColor),
+		Muted:     lipgloss.Color(theme.MutedColor),
+		Bg:        lipgloss.Color(theme.BackgroundColor),
+		BgLight:   lipgloss.Color(theme.HighlightColor),
+		Fg:        lipgloss.Color(theme.ForegroundColor),
+	}
+
+	s := &Styles{Theme: theme, Colors: c, Currency: currency}
 
-	SuccessStyle = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+	// Base styles
+	s.Title = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(c.Primary).
+		MarginBottom(1)
+	s.Subtitle = lipgloss.NewStyle().
+		Foreground(c.Secondary).
+		MarginBottom(1)
+	s.Error = lipgloss.NewStyle().
+		Foreground(c.Error).
+		Bold(true).
+		Padding(0, 1)
+	s.Loading = lipgloss.NewStyle().
+		Foreground(c.Warning).
+		Bold(true)
+	s.Selected = lipgloss.NewStyle().
+		Background(c.BgLight).
+		Foreground(c.Fg).
+		Bold(true).
+		Padding(0, 1)
+	s.Normal = lipgloss.NewStyle().
+		Foreground(c.Fg).
+		Padding(0, 1)
+	s.Price = lipgloss.NewStyle().
+		Foreground(c.Accent).
+		Bold(true)
+	s.Brand = lipgloss.NewStyle().
+		Foreground(c.Secondary)
+	s.Help = lipgloss.NewStyle().
+		Foreground(c.Muted).
+		MarginTop(1)
+	s.Success = lipgloss.NewStyle().
+		Foreground(c.Success).
+		Bold(true)
 
 	// Box styles
-	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorMuted).
-			Padding(1, 2)
-
-	HeaderBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(0, 2).
-			MarginBottom(1)
+	s.Box = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(c.Muted).
+		Padding(1, 2)
+	s.HeaderBox = lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(c.Primary).
+		Padding(0, 2).
+		MarginBottom(1)
 
 	// Notification styles
-	NotificationSuccessStyle = lipgloss.NewStyle().
-					Background(ColorAccent).
-					Foreground(ColorBg).
-					Bold(true).
-					Padding(0, 2).
-					MarginTop(1)
-
-	NotificationErrorStyle = lipgloss.NewStyle().
-				Background(ColorError).
-				Foreground(ColorFg).
-				Bold(true).
-				Padding(0, 2).
-				MarginTop(1)
-
-	NotificationInfoStyle = lipgloss.NewStyle().
-				Background(ColorSecondary).
-				Foreground(ColorBg).
-				Bold(true).
-				Padding(0, 2).
-				MarginTop(1)
+	s.NotificationSuccess = lipgloss.NewStyle().
+		Background(c.Accent).
+		Foreground(c.Bg).
+		Bold(true).
+		Padding(0, 2).
+		MarginTop(1)
+	s.NotificationError = lipgloss.NewStyle().
+		Background(c.Error).
+		Foreground(c.Fg).
+		Bold(true).
+		Padding(0, 2).
+		MarginTop(1)
+	s.NotificationInfo = lipgloss.NewStyle().
+		Background(c.Secondary).
+		Foreground(c.Bg).
+		Bold(true).
+		Padding(0, 2).
+		MarginTop(1)
+
+	// Degraded-mode banner
+	s.Banner = lipgloss.NewStyle().
+		Background(c.Warning).
+		Foreground(c.Bg).
+		Bold(true).
+		Padding(0, 1)
 
 	// Input styles
-	InputStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(ColorMuted).
-			Padding(0, 1)
-
-	InputFocusedStyle = lipgloss.NewStyle().
-				Border(lipgloss.NormalBorder()).
-				BorderForeground(ColorPrimary).
-				Padding(0, 1)
+	s.Input = lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(c.Muted).
+		Padding(0, 1)
+	s.InputFocused = lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(c.Primary).
+		Padding(0, 1)
 
 	// Footer style
-	FooterStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Border(lipgloss.NormalBorder(), true, false, false, false).
-			BorderForeground(ColorMuted).
-			PaddingTop(1).
-			MarginTop(1)
+	s.Footer = lipgloss.NewStyle().
+		Foreground(c.Muted).
+		Border(lipgloss.NormalBorder(), true, false, false, false).
+		BorderForeground(c.Muted).
+		PaddingTop(1).
+		MarginTop(1)
 
 	// Divider style
-	DividerStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+	s.Divider = lipgloss.NewStyle().
+		Foreground(c.Muted)
 
 	// Product card style
-	ProductCardStyle = lipgloss.NewStyle().
-				Padding(0, 1)
-
-	ProductCardSelectedStyle = lipgloss.NewStyle().
-					Background(ColorBgLight).
-					Padding(0, 1)
+	s.ProductCard = lipgloss.NewStyle().
+		Padding(0, 1)
+	s.ProductCardSelected = lipgloss.NewStyle().
+		Background(c.BgLight).
+		Padding(0, 1)
 
 	// Badge style
-	BadgeStyle = lipgloss.NewStyle().
-			Background(ColorPrimary).
-			Foreground(ColorBg).
-			Padding(0, 1).
-			Bold(true)
-
-	CartBadgeStyle = lipgloss.NewStyle().
-			Background(ColorAccent).
-			Foreground(ColorBg).
-			Padding(0, 1).
-			Bold(true)
+	s.Badge = lipgloss.NewStyle().
+		Background(c.Primary).
+		Foreground(c.Bg).
+		Padding(0, 1).
+		Bold(true)
+	s.CartBadge = lipgloss.NewStyle().
+		Background(c.Accent).
+		Foreground(c.Bg).
+		Padding(0, 1).
+		Bold(true)
 
 	// Option row styles
-	OptionLabelStyle = lipgloss.NewStyle().
-				Foreground(ColorSecondary).
-				Width(12)
-
-	OptionValueStyle = lipgloss.NewStyle().
-				Foreground(ColorFg)
-
-	OptionValueSelectedStyle = lipgloss.NewStyle().
-					Background(ColorPrimary).
-					Foreground(ColorBg).
-					Bold(true).
-					Padding(0, 1)
-
-	OptionValueUnselectedStyle = lipgloss.NewStyle().
-					Foreground(ColorMuted).
-					Padding(0, 1)
-
-	OptionRowFocusedStyle = lipgloss.NewStyle().
-				Background(ColorBgLight).
-				Padding(0, 1)
-
-	OptionRowStyle = lipgloss.NewStyle().
-			Padding(0, 1)
-)
+	s.OptionLabel = lipgloss.NewStyle().
+		Foreground(c.Secondary).
+		Width(12)
+	s.OptionValue = lipgloss.NewStyle().
+		Foreground(c.Fg)
+	s.OptionValueSelected = lipgloss.NewStyle().
+		Background(c.Primary).
+		Foreground(c.Bg).
+		Bold(true).
+		Padding(0, 1)
+	s.OptionValueUnselected = lipgloss.NewStyle().
+		Foreground(c.Muted).
+		Padding(0, 1)
+	s.OptionRowFocused = lipgloss.NewStyle().
+		Background(c.BgLight).
+		Padding(0, 1)
+	s.OptionRow = lipgloss.NewStyle().
+		Padding(0, 1)
+	return s
+}
 
 const AsciiLogo = `
 ▖ ▖  ▘    ▄▖                  
@@ -1,20 +1,46 @@
 package tui
 
 import (
-	"fmt"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"strings"
 	"time"
+	"unicode/utf8"
+	"terminal-echoware/internal/api"
 	"terminal-echoware/pkg/types"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func (m *Model) Init() tea.Cmd {
-	loadingCmd := m.SetLoading(true, "Loading products...")
-	return tea.Batch(tea.ClearScreen, loadingCmd, loadProductsCmd(m.apiClient, 0, 20))
+	cmds := []tea.Cmd{tea.ClearScreen, m.loadHomeProducts()}
+	if saved := m.savedCart(); len(saved) > 0 {
+		// Not a cancellable request: it runs alongside the product list.
+		cmds = append(cmds, restoreCartCmd(m.ctx, m.backend, saved))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadHomeProducts fetches the home screen's product list.
+func (m *Model) loadHomeProducts() tea.Cmd {
+	loadingCmd := m.SetLoading(true, m.tr.T("loading.products"))
+	return tea.Batch(loadingCmd, loadProductsCmd(m.newRequest(), m.backend, 0, 20))
 }
 
+// Update handles msg and, when it moved the session to another screen,
+// picks up any config reloaded since.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prev := m.screen
+	model, cmd := m.update(msg)
+	if m.screen != prev {
+		m.refreshConfig()
+	}
+	return model, cmd
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -42,11 +68,28 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// Ctrl+C always quits, whatever the key map says.
+		if msg.Type == tea.KeyCtrlC {
+			m.CancelRequest()
+			return m, tea.Quit
+		}
 		if m.loading {
+			switch {
+			case m.matches(msg, m.keys.Quit):
+				m.CancelRequest()
+				return m, tea.Quit
+			case m.matches(msg, m.keys.Back, m.keys.Cancel):
+				m.CancelRequest()
+				m.SetLoading(false, "")
+				return m, m.SetNotification(m.tr.T("notify.cancelled"), "info")
+			}
 			return m, nil
 		}
-		if msg.String() == "ctrl+c" {
-			return m, tea.Quit
+		if m.matches(msg, m.keys.Theme) {
+			return m, m.CycleTheme()
+		}
+		if m.matches(msg, m.keys.Language) {
+			return m, m.CycleLanguage()
 		}
 		
 		// Handle scroll keys for viewport
@@ -74,6 +117,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case orderCreatedMsg:
 		return m.handleOrderCreated(msg)
+
+	case cartRestoredMsg:
+		return m.handleCartRestored(msg)
 	}
 
 	// Update viewport
@@ -84,7 +130,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleProductsLoaded(msg productsLoadedMsg) (tea.Model, tea.Cmd) {
-	m.SetLoading(false, "")
+	if !m.finishRequest(msg.seq) {
+		return m, nil
+	}
 	if msg.err != nil {
 		m.SetError(msg.err)
 		return m, nil
@@ -97,7 +145,9 @@ func (m *Model) handleProductsLoaded(msg productsLoadedMsg) (tea.Model, tea.Cmd)
 }
 
 func (m *Model) handleProductLoaded(msg productLoadedMsg) (tea.Model, tea.Cmd) {
-	m.SetLoading(false, "")
+	if !m.finishRequest(msg.seq) {
+		return m, nil
+	}
 	if msg.err != nil {
 		m.SetError(msg.err)
 		return m, nil
@@ -113,7 +163,9 @@ func (m *Model) handleProductLoaded(msg productLoadedMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleSearchResults(msg searchResultsMsg) (tea.Model, tea.Cmd) {
-	m.SetLoading(false, "")
+	if !m.finishRequest(msg.seq) {
+		return m, nil
+	}
 	if msg.err != nil {
 		m.SetError(msg.err)
 		return m, nil
@@ -127,12 +179,17 @@ func (m *Model) handleSearchResults(msg searchResultsMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleOrderCreated(msg orderCreatedMsg) (tea.Model, tea.Cmd) {
-	m.SetLoading(false, "")
+	if !m.finishRequest(msg.seq) {
+		return m, nil
+	}
 	if msg.err != nil {
 		m.SetError(msg.err)
 		return m, nil
 	}
 	m.order = msg.order
+	m.rememberOrder()
+	m.checkoutKey = ""
+	m.checkoutDigest = ""
 	m.screen = types.ScreenOrderSuccess
 	m.ClearError()
 	m.viewport.GotoTop()
@@ -140,6 +197,49 @@ func (m *Model) handleOrderCreated(msg orderCreatedMsg) (tea.Model, tea.Cmd) {
 	return m, tea.Sequence(tea.ClearScreen, tea.WindowSize())
 }
 
+// rememberOrder adds the order and the address it shipped to to the
+// customer's account. The store writes them out in the background, so
+// saving never delays or fails the order itself.
+func (m *Model) rememberOrder() {
+	if m.identity.Guest() {
+		return
+	}
+	m.accounts.RecordOrder(m.identity, m.overlay.Storefront, *m.order)
+	m.accounts.SaveAddress(m.identity, m.address)
+	// The cart has been ordered; don't bring it back on the next connect.
+	m.accounts.SaveCart(m.identity, m.overlay.Storefront, nil)
+}
+
+// handleCartRestored merges the checked saved cart into the session's cart
+// and tells the customer what came back and what changed.
+func (m *Model) handleCartRestored(msg cartRestoredMsg) (tea.Model, tea.Cmd) {
+	restored := 0
+	for _, item := range msg.items {
+		// Anything over the limit is capped; there is nobody to tell yet.
+		_ = m.cart.Add(item.Product, item.Quantity, item.Variant)
+		restored += item.Quantity
+	}
+	m.saveCart()
+
+	var parts []string
+	if restored > 0 {
+		parts = append(parts, m.tr.Plural("notify.cart_restored", restored))
+	}
+	if msg.removed > 0 {
+		parts = append(parts, m.tr.Plural("notify.cart_removed", msg.removed))
+	}
+	if msg.repriced > 0 {
+		parts = append(parts, m.tr.Plural("notify.cart_repriced", msg.repriced))
+	}
+	if msg.unverified > 0 {
+		parts = append(parts, m.tr.Plural("notify.cart_unverified", msg.unverified))
+	}
+	if len(parts) == 0 {
+		return m, nil
+	}
+	return m, m.SetNotification(strings.Join(parts, " • "), "info")
+}
+
 func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.screen {
 	case types.ScreenHome:
@@ -156,76 +256,83 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleCheckoutKeys(msg)
 	case types.ScreenOrderSuccess:
 		return m.handleOrderSuccessKeys(msg)
+	case types.ScreenOrders:
+		return m.handleOrdersKeys(msg)
 	}
 	return m, nil
 }
 
 func (m *Model) handleHomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q":
+	k := m.keys
+	switch {
+	case m.matches(msg, k.Quit):
 		return m, tea.Quit
-	case "up", "k":
+	case m.matches(msg, k.Up):
 		m.NavigateUp()
 		return m, nil
-	case "down", "j":
+	case m.matches(msg, k.Down):
 		m.NavigateDown(len(m.homeProducts) - 1)
 		return m, nil
-	case "enter", " ":
-		if len(m.homeProducts) > 0 && m.cursor < len(m.homeProducts) {
-			loadingCmd := m.SetLoading(true, "Loading product...")
-			return m, tea.Batch(loadingCmd, loadProductCmd(m.apiClient, m.homeProducts[m.cursor].ID))
+	case m.matches(msg, k.Select):
+		if len(m.homeProducts) == 0 {
+			// Nothing loaded yet: the first load was cancelled or failed.
+			return m, m.loadHomeProducts()
+		}
+		if m.cursor < len(m.homeProducts) {
+			loadingCmd := m.SetLoading(true, m.tr.T("loading.product"))
+			return m, tea.Batch(loadingCmd, loadProductCmd(m.newRequest(), m.backend, m.homeProducts[m.cursor].ID))
 		}
 		return m, nil
-	case "s", "/":
+	case m.matches(msg, k.Search):
 		cmd := m.GoToScreen(types.ScreenSearch)
 		m.searchQuery = ""
 		m.searchResults = nil
 		return m, cmd
-	case "c":
+	case m.matches(msg, k.Cart):
 		return m, m.GoToScreen(types.ScreenCart)
+	case m.matches(msg, k.Orders):
+		return m, m.GoToScreen(types.ScreenOrders)
 	}
 	return m, nil
 }
 
 func (m *Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	key := msg.String()
-	
-	// Handle special keys first
-	switch key {
-	case "esc":
+	k := m.keys
+
+	// Handle bound keys first; printable keys never match here
+	switch {
+	case m.matches(msg, k.Back):
 		cmd := m.GoToScreen(types.ScreenHome)
 		m.searchResults = nil
 		return m, cmd
-	case "ctrl+c":
+	case m.matches(msg, k.Quit):
 		return m, tea.Quit
-	case "backspace":
-		if len(m.searchQuery) > 0 {
-			m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
-		}
+	case msg.Type == tea.KeyBackspace:
+		m.searchQuery = dropLastRune(m.searchQuery)
 		return m, nil
-	case "enter":
+	case m.matches(msg, k.Select):
 		// If we have search results and cursor is on a product, open it
 		if len(m.searchResults) > 0 && m.cursor < len(m.searchResults) {
-			loadingCmd := m.SetLoading(true, "Loading product...")
-			return m, tea.Batch(loadingCmd, loadProductCmd(m.apiClient, m.searchResults[m.cursor].ID))
+			loadingCmd := m.SetLoading(true, m.tr.T("loading.product"))
+			return m, tea.Batch(loadingCmd, loadProductCmd(m.newRequest(), m.backend, m.searchResults[m.cursor].ID))
 		}
 		// Otherwise, perform search
 		if len(m.searchQuery) > 0 {
-			loadingCmd := m.SetLoading(true, fmt.Sprintf("Searching for '%s'...", m.searchQuery))
-			return m, tea.Batch(loadingCmd, searchProductsCmd(m.apiClient, m.searchQuery, 0, 20))
+			loadingCmd := m.SetLoading(true, m.tr.T("loading.search", m.searchQuery))
+			return m, tea.Batch(loadingCmd, searchProductsCmd(m.newRequest(), m.backend, m.searchQuery, 0, 20))
 		}
 		return m, nil
-	case "tab":
+	case m.matches(msg, k.NextField):
 		// Tab to search with current query
 		if len(m.searchQuery) > 0 {
-			loadingCmd := m.SetLoading(true, fmt.Sprintf("Searching for '%s'...", m.searchQuery))
-			return m, tea.Batch(loadingCmd, searchProductsCmd(m.apiClient, m.searchQuery, 0, 20))
+			loadingCmd := m.SetLoading(true, m.tr.T("loading.search", m.searchQuery))
+			return m, tea.Batch(loadingCmd, searchProductsCmd(m.newRequest(), m.backend, m.searchQuery, 0, 20))
 		}
 		return m, nil
-	case "up":
+	case m.matches(msg, k.Up):
 		m.NavigateUp()
 		return m, nil
-	case "down":
+	case m.matches(msg, k.Down):
 		m.NavigateDown(len(m.searchResults) - 1)
 		return m, nil
 	}
@@ -238,10 +345,11 @@ func (m *Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleProductKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q":
+	k := m.keys
+	switch {
+	case m.matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc", "b":
+	case m.matches(msg, k.Back):
 		// Go back to previous screen
 		if m.previousScreen == types.ScreenSearch {
 			m.screen = types.ScreenSearch
@@ -254,31 +362,29 @@ func (m *Model) handleProductKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.viewport.GotoTop()
 		m.viewport.SetContent("")
 		return m, tea.Sequence(tea.ClearScreen, tea.WindowSize())
-	case "a":
+	case m.matches(msg, k.AddToCart, k.Select):
 		return m.addToCart()
-	case "enter":
-		return m.addToCart()
-	case "tab":
+	case m.matches(msg, k.NextField):
 		m.MoveFocusDown()
 		return m, nil
-	case "shift+tab":
+	case m.matches(msg, k.PrevField):
 		m.MoveFocusUp()
 		return m, nil
-	case "up", "k":
+	case m.matches(msg, k.Up):
 		// Scroll viewport up
 		m.viewport.LineUp(1)
 		return m, nil
-	case "down", "j":
+	case m.matches(msg, k.Down):
 		// Scroll viewport down
 		m.viewport.LineDown(1)
 		return m, nil
-	case "left", "h":
+	case m.matches(msg, k.OptionLeft):
 		m.CycleVariantLeft()
 		return m, nil
-	case "right", "l":
+	case m.matches(msg, k.OptionRight):
 		m.CycleVariantRight()
 		return m, nil
-	case "c":
+	case m.matches(msg, k.Cart):
 		return m, m.GoToScreen(types.ScreenCart)
 	}
 	return m, nil
@@ -293,64 +399,83 @@ func (m *Model) addToCart() (tea.Model, tea.Cmd) {
 	if len(m.currentProduct.ProductVariants) > 0 {
 		variantStr := m.GetSelectedVariantString()
 		err := m.cart.Add(*m.currentProduct, m.productQuantity, m.GetSelectedVariants())
+		m.saveCart()
 		if err != nil {
-			return m, m.SetNotification(err.Error(), "error")
+			return m, m.cartError(err)
 		}
-		return m, m.SetNotification(fmt.Sprintf("Added %d (%s) to cart!", m.productQuantity, variantStr), "success")
+		return m, m.SetNotification(m.tr.Plural("notify.added_variant", m.productQuantity, variantStr), "success")
 	}
 	
 	err := m.cart.Add(*m.currentProduct, m.productQuantity, nil)
+	m.saveCart()
 	if err != nil {
-		return m, m.SetNotification(err.Error(), "error")
+		return m, m.cartError(err)
+	}
+	return m, m.SetNotification(m.tr.Plural("notify.added", m.productQuantity), "success")
+}
+
+func (m *Model) cartError(err error) tea.Cmd {
+	if errors.Is(err, types.ErrQuantityLimit) {
+		return m.SetNotification(m.tr.T("notify.max_quantity", types.MaxCartQuantity), "error")
 	}
-	return m, m.SetNotification(fmt.Sprintf("Added %d to cart!", m.productQuantity), "success")
+	return m.SetNotification(err.Error(), "error")
 }
 
 func (m *Model) handleCartKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q":
+	k := m.keys
+	switch {
+	case m.matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc", "b":
+	case m.matches(msg, k.Back):
 		return m, m.GoToScreen(types.ScreenHome)
-	case "up", "k":
+	case m.matches(msg, k.Up):
 		m.NavigateUp()
 		return m, nil
-	case "down", "j":
+	case m.matches(msg, k.Down):
 		m.NavigateDown(len(m.cart.Items) - 1)
 		return m, nil
-	case "+", "=":
+	case m.matches(msg, k.Increase):
 		if m.cursor < len(m.cart.Items) {
 			item := &m.cart.Items[m.cursor]
-			const maxQuantity = 5
-			if item.Quantity >= maxQuantity {
-				return m, m.SetNotification(fmt.Sprintf("Maximum quantity of %d reached for this item", maxQuantity), "error")
+			if item.Quantity >= types.MaxCartQuantity {
+				return m, m.SetNotification(m.tr.T("notify.max_quantity", types.MaxCartQuantity), "error")
 			}
 			item.Quantity++
-			return m, m.SetNotification("Quantity increased", "info")
+			m.saveCart()
+			return m, m.SetNotification(m.tr.T("notify.quantity_up"), "info")
 		}
 		return m, nil
-	case "-", "_":
+	case m.matches(msg, k.Decrease):
 		if m.cursor < len(m.cart.Items) {
 			item := &m.cart.Items[m.cursor]
 			if item.Quantity > 1 {
 				item.Quantity--
-				return m, m.SetNotification("Quantity decreased", "info")
+				m.saveCart()
+				return m, m.SetNotification(m.tr.T("notify.quantity_down"), "info")
 			}
 		}
 		return m, nil
-	case "d", "x":
+	case m.matches(msg, k.Delete):
 		if m.cursor < len(m.cart.Items) {
 			name := m.cart.Items[m.cursor].Product.Name
 			m.cart.Remove(m.cart.Items[m.cursor].Product.ID, m.cart.Items[m.cursor].Variant)
+			m.saveCart()
 			if m.cursor >= len(m.cart.Items) && m.cursor > 0 {
 				m.cursor--
 			}
-			return m, m.SetNotification(fmt.Sprintf("Removed %s", truncate(name, 20)), "info")
+			return m, m.SetNotification(m.tr.T("notify.removed", truncate(name, 20)), "info")
 		}
 		return m, nil
-	case "enter", " ":
+	case m.matches(msg, k.Select):
 		if len(m.cart.Items) > 0 {
-			return m, m.GoToScreen(types.ScreenAddress)
+			if m.Degraded() {
+				return m, m.SetNotification(m.tr.T("notify.checkout_offline"), "error")
+			}
+			cmd := m.GoToScreen(types.ScreenAddress)
+			if m.address == (types.ShippingDetails{}) {
+				m.useSavedAddress(0)
+			}
+			return m, cmd
 		}
 		return m, nil
 	}
@@ -358,13 +483,14 @@ func (m *Model) handleCartKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleAddressKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	k := m.keys
+	switch {
+	case m.matches(msg, k.Back):
 		m.screen = types.ScreenCart
 		m.viewport.GotoTop()
 		m.viewport.SetContent("")
 		return m, tea.Sequence(tea.ClearScreen, tea.WindowSize())
-	case "enter":
+	case m.matches(msg, k.Select):
 		if errMsg := m.validateShippingDetails(); errMsg != "" {
 			return m, m.SetNotification(errMsg, "error")
 		}
@@ -372,13 +498,23 @@ func (m *Model) handleAddressKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.viewport.GotoTop()
 		m.viewport.SetContent("")
 		return m, tea.Sequence(tea.ClearScreen, tea.WindowSize())
-	case "tab", "down":
+	case m.matches(msg, k.NextField, k.Down):
 		m.cursor = (m.cursor + 1) % 9
 		return m, nil
-	case "up":
+	case m.matches(msg, k.PrevField, k.Up):
 		m.cursor = (m.cursor + 8) % 9
 		return m, nil
-	case "backspace":
+	case m.matches(msg, k.OptionLeft):
+		if m.cyclingSavedAddresses() {
+			m.useSavedAddress(m.addressIndex - 1)
+		}
+		return m, nil
+	case m.matches(msg, k.OptionRight):
+		if m.cyclingSavedAddresses() {
+			m.useSavedAddress(m.addressIndex + 1)
+		}
+		return m, nil
+	case msg.Type == tea.KeyBackspace:
 		m.handleAddressBackspace()
 		return m, nil
 	default:
@@ -392,44 +528,33 @@ func (m *Model) handleAddressKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleAddressBackspace() {
 	switch m.cursor {
 	case 0:
-		if len(m.address.FullName) > 0 {
-			m.address.FullName = m.address.FullName[:len(m.address.FullName)-1]
-		}
+		m.address.FullName = dropLastRune(m.address.FullName)
 	case 1:
-		if len(m.address.Phone) > 0 {
-			m.address.Phone = m.address.Phone[:len(m.address.Phone)-1]
-		}
+		m.address.Phone = dropLastRune(m.address.Phone)
 	case 2:
-		if len(m.address.Email) > 0 {
-			m.address.Email = m.address.Email[:len(m.address.Email)-1]
-		}
+		m.address.Email = dropLastRune(m.address.Email)
 	case 3:
-		if len(m.address.AddressLine1) > 0 {
-			m.address.AddressLine1 = m.address.AddressLine1[:len(m.address.AddressLine1)-1]
-		}
+		m.address.AddressLine1 = dropLastRune(m.address.AddressLine1)
 	case 4:
-		if len(m.address.AddressLine2) > 0 {
-			m.address.AddressLine2 = m.address.AddressLine2[:len(m.address.AddressLine2)-1]
-		}
+		m.address.AddressLine2 = dropLastRune(m.address.AddressLine2)
 	case 5:
-		if len(m.address.City) > 0 {
-			m.address.City = m.address.City[:len(m.address.City)-1]
-		}
+		m.address.City = dropLastRune(m.address.City)
 	case 6:
-		if len(m.address.State) > 0 {
-			m.address.State = m.address.State[:len(m.address.State)-1]
-		}
+		m.address.State = dropLastRune(m.address.State)
 	case 7:
-		if len(m.address.PostalCode) > 0 {
-			m.address.PostalCode = m.address.PostalCode[:len(m.address.PostalCode)-1]
-		}
+		m.address.PostalCode = dropLastRune(m.address.PostalCode)
 	case 8:
-		if len(m.address.Country) > 0 {
-			m.address.Country = m.address.Country[:len(m.address.Country)-1]
-		}
+		m.address.Country = dropLastRune(m.address.Country)
 	}
 }
 
+// dropLastRune removes the last character of s, which may be several bytes
+// long in scripts such as Devanagari.
+func dropLastRune(s string) string {
+	_, size := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-size]
+}
+
 func (m *Model) handleAddressInput(input string) {
 	switch m.cursor {
 	case 0:
@@ -454,30 +579,29 @@ func (m *Model) handleAddressInput(input string) {
 }
 
 func (m *Model) handleCheckoutKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "b":
+	k := m.keys
+	switch {
+	case m.matches(msg, k.Back, k.Cancel):
 		m.screen = types.ScreenAddress
 		m.viewport.GotoTop()
 		m.viewport.SetContent("")
 		return m, tea.Sequence(tea.ClearScreen, tea.WindowSize())
-	case "enter", "y":
+	case m.matches(msg, k.Select, k.Confirm):
 		if errMsg := m.validateShippingDetails(); errMsg != "" {
 			return m, m.SetNotification(errMsg, "error")
 		}
 		return m.placeOrder()
-	case "n":
-		m.screen = types.ScreenAddress
-		m.viewport.GotoTop()
-		m.viewport.SetContent("")
-		return m, tea.Sequence(tea.ClearScreen, tea.WindowSize())
 	}
 	return m, nil
 }
 
 func (m *Model) placeOrder() (tea.Model, tea.Cmd) {
+	if m.Degraded() {
+		return m, m.SetNotification(m.tr.T("notify.checkout_offline"), "error")
+	}
 	var orderItems []types.OrderItemInput
 	for _, item := range m.cart.Items {
-		total := item.Product.SellingPrice * float64(item.Quantity)
+		total := item.Total()
 		orderItems = append(orderItems, types.OrderItemInput{
 			ProductID:   item.Product.ID,
 			ProductName: item.Product.Name,
@@ -489,9 +613,8 @@ func (m *Model) placeOrder() (tea.Model, tea.Cmd) {
 	}
 
 	subtotal := m.cart.Total()
-	discount := 0.0
-	shipping := 0.0
-	total := subtotal - discount + shipping
+	var discount, shipping types.Money
+	total := subtotal.Sub(discount).Add(shipping)
 
 	m.address.Address = ""
 	m.address.IsDefault = false
@@ -510,9 +633,33 @@ func (m *Model) placeOrder() (tea.Model, tea.Cmd) {
 		Timestamp:     time.Now().Format(time.RFC3339),
 		PaymentMethod: "cod",
 	}
+	params.IdempotencyKey = m.checkoutKeyFor(params)
+
+	loadingCmd := m.SetLoading(true, m.tr.T("loading.order"))
+	return m, tea.Batch(loadingCmd, createOrderCmd(m.newRequest(), m.backend, params))
+}
+
+// checkoutKeyFor returns the idempotency key for this checkout. Pressing
+// Enter again after a failure reuses the key so the backend can deduplicate;
+// a new key is only minted once the cart or address has changed.
+func (m *Model) checkoutKeyFor(params types.OrderCreateParams) string {
+	digest := checkoutDigest(params)
+	if m.checkoutKey == "" || digest != m.checkoutDigest {
+		m.checkoutKey = api.NewIdempotencyKey()
+		m.checkoutDigest = digest
+	}
+	return m.checkoutKey
+}
 
-	loadingCmd := m.SetLoading(true, "Placing order...")
-	return m, tea.Batch(loadingCmd, createOrderCmd(m.apiClient, params))
+func checkoutDigest(params types.OrderCreateParams) string {
+	params.Timestamp = ""
+	params.IdempotencyKey = ""
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
 }
 
 func (m *Model) validateShippingDetails() string {
@@ -526,28 +673,28 @@ func (m *Model) validateShippingDetails() string {
 	country := strings.TrimSpace(m.address.Country)
 
 	if fullName == "" {
-		return "Full name is required"
+		return m.tr.T("invalid.full_name")
 	}
 	if phone == "" || !isValidPhone(phone) {
-		return "Enter a valid phone number"
+		return m.tr.T("invalid.phone")
 	}
 	if email == "" || !isValidEmail(email) {
-		return "Enter a valid email address"
+		return m.tr.T("invalid.email")
 	}
 	if address1 == "" {
-		return "Address line 1 is required"
+		return m.tr.T("invalid.address_line1")
 	}
 	if city == "" {
-		return "City is required"
+		return m.tr.T("invalid.city")
 	}
 	if state == "" {
-		return "State is required"
+		return m.tr.T("invalid.state")
 	}
 	if postal == "" || len(postal) < 4 {
-		return "Enter a valid postal code"
+		return m.tr.T("invalid.postal_code")
 	}
 	if country == "" {
-		return "Country is required"
+		return m.tr.T("invalid.country")
 	}
 	return ""
 }
@@ -571,8 +718,8 @@ func isValidPhone(phone string) bool {
 }
 
 func (m *Model) handleOrderSuccessKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", "esc", " ":
+	switch {
+	case m.matches(msg, m.keys.Select, m.keys.Back):
 		cmd := m.GoToScreen(types.ScreenHome)
 		m.ClearCart()
 		m.order = nil
@@ -581,3 +728,21 @@ func (m *Model) handleOrderSuccessKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 	return m, nil
 }
+
+func (m *Model) handleOrdersKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keys
+	switch {
+	case m.matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.matches(msg, k.Back):
+		return m, m.GoToScreen(types.ScreenHome)
+	case m.matches(msg, k.Up):
+		m.NavigateUp()
+		return m, nil
+	case m.matches(msg, k.Down):
+		c, _ := m.customer()
+		m.NavigateDown(len(c.Orders) - 1)
+		return m, nil
+	}
+	return m, nil
+}
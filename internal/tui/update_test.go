@@ -0,0 +1,286 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"terminal-echoware/internal/account"
+	"terminal-echoware/internal/api"
+	"terminal-echoware/pkg/config"
+	"terminal-echoware/pkg/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var testProducts = []types.Product{
+	{ID: "p1", Name: "Tee", Active: true, SellingPrice: types.NewMoney(79900, "")},
+	{ID: "p2", Name: "Mug", Active: true, SellingPrice: types.NewMoney(34950, "")},
+	{ID: "p3", Name: "Sticker", Active: true, SellingPrice: types.NewMoney(14900, "")},
+}
+
+// newTestModel returns a session over an in-memory catalog, for a guest
+// unless identity says otherwise.
+func newTestModel(t *testing.T, accounts *account.Store, identity account.Identity) *Model {
+	t.Helper()
+	return newTestModelOn(t, api.NewMemoryBackend(testProducts, nil), accounts, identity)
+}
+
+func newTestModelOn(t *testing.T, backend api.Backend, accounts *account.Store, identity account.Identity) *Model {
+	t.Helper()
+	store := config.NewStore("", config.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return NewModel(ctx, backend, store, config.Overlay{}, accounts, identity)
+}
+
+// timerMsg is what the spinner and notification timers produce in tests.
+type timerMsg struct{}
+
+func TestMain(m *testing.M) {
+	// Timers fire at once with a message run ignores, so tests never wait
+	// on the spinner or on notifications clearing.
+	after = func(time.Duration, func(time.Time) tea.Msg) tea.Cmd {
+		return func() tea.Msg { return timerMsg{} }
+	}
+	os.Exit(m.Run())
+}
+
+// run executes cmd and feeds the message it produces back into m, the way
+// bubbletea would. Batches run in order; timers are skipped.
+func run(m *Model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			run(m, c)
+		}
+	case productsLoadedMsg, productLoadedMsg, searchResultsMsg, orderCreatedMsg, cartRestoredMsg:
+		_, next := m.Update(msg)
+		run(m, next)
+	}
+}
+
+func press(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestStaleRepliesAreDropped(t *testing.T) {
+	m := newTestModel(t, nil, account.Identity{})
+	m.Init()
+	first := m.request
+
+	// The first request is cancelled and a newer one started before the
+	// first reply arrives.
+	m.Update(press("esc"))
+	m.homeProducts = testProducts
+	m.Update(press("enter"))
+	if m.request == first || !m.loading {
+		t.Fatalf("opening a product did not start a new request")
+	}
+
+	m.Update(productsLoadedMsg{seq: first, products: testProducts[:1]})
+	if !m.loading || len(m.homeProducts) != len(testProducts) {
+		t.Fatalf("stale product list was applied")
+	}
+
+	p := testProducts[0]
+	m.Update(productLoadedMsg{seq: m.request, product: &p})
+	if m.loading || m.screen != types.ScreenProduct || m.currentProduct.ID != "p1" {
+		t.Fatalf("current reply was not applied: loading=%v screen=%v", m.loading, m.screen)
+	}
+}
+
+func TestCancelledReplyIsDropped(t *testing.T) {
+	m := newTestModel(t, nil, account.Identity{})
+	m.Init()
+	seq := m.request
+
+	m.Update(press("esc"))
+	if m.loading || m.request != 0 {
+		t.Fatalf("esc did not cancel the request")
+	}
+	m.Update(productsLoadedMsg{seq: seq, products: testProducts})
+	if len(m.homeProducts) != 0 {
+		t.Fatalf("reply to the cancelled request was applied")
+	}
+}
+
+func TestReloadAfterCancelledFirstLoad(t *testing.T) {
+	m := newTestModel(t, nil, account.Identity{})
+	m.Init()
+	m.Update(press("esc"))
+
+	_, cmd := m.Update(press("enter"))
+	if !m.loading {
+		t.Fatal("enter on an empty home screen did not reload")
+	}
+	run(m, cmd)
+	if m.loading || len(m.homeProducts) != len(testProducts) {
+		t.Fatalf("reload gave %d products, loading=%v", len(m.homeProducts), m.loading)
+	}
+}
+
+func TestLoadingKeysFollowKeyMap(t *testing.T) {
+	m := newTestModel(t, nil, account.Identity{})
+	m.overlay.KeyBindings = map[string]config.KeyBinding{config.ActionBack: {Keys: []string{"x"}}}
+	m.applyConfig()
+	m.Init()
+
+	m.Update(press("esc"))
+	if !m.loading {
+		t.Fatal("esc cancelled although back is bound to x only")
+	}
+	m.Update(press("x"))
+	if m.loading {
+		t.Fatal("the back binding did not cancel the request")
+	}
+}
+
+// loaded returns a session whose home screen has finished loading.
+func loaded(t *testing.T, accounts *account.Store, identity account.Identity) *Model {
+	t.Helper()
+	m := newTestModel(t, accounts, identity)
+	run(m, m.Init())
+	if m.loading || len(m.homeProducts) != len(testProducts) {
+		t.Fatalf("home screen did not load")
+	}
+	return m
+}
+
+func TestHomeNavigation(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want int
+	}{
+		{nil, 0},
+		{[]string{"down"}, 1},
+		{[]string{"j", "j"}, 2},
+		{[]string{"j", "j", "j", "j"}, 2},
+		{[]string{"j", "k"}, 0},
+		{[]string{"k"}, 0},
+	}
+	for _, tt := range tests {
+		m := loaded(t, nil, account.Identity{})
+		for _, k := range tt.keys {
+			m.Update(press(k))
+		}
+		if m.cursor != tt.want {
+			t.Errorf("%v: cursor = %d, want %d", tt.keys, m.cursor, tt.want)
+		}
+	}
+}
+
+func TestSearchTyping(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{"letters", []string{"m", "u", "g"}, "mug"},
+		{"bound keys are text", []string{"q", "s", "j", " "}, "qsj "},
+		{"backspace", []string{"m", "u", "backspace"}, "m"},
+		{"backspace on empty", []string{"backspace"}, ""},
+		{"devanagari", []string{"क", "ि", "backspace"}, "क"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := loaded(t, nil, account.Identity{})
+			m.Update(press("/"))
+			if m.screen != types.ScreenSearch {
+				t.Fatalf("screen = %v, want search", m.screen)
+			}
+			for _, k := range tt.keys {
+				m.Update(press(k))
+			}
+			if m.searchQuery != tt.want {
+				t.Errorf("query = %q, want %q", m.searchQuery, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchAndOpen(t *testing.T) {
+	m := loaded(t, nil, account.Identity{})
+	m.Update(press("/"))
+	for _, r := range "mug" {
+		m.Update(press(string(r)))
+	}
+	_, cmd := m.Update(press("enter"))
+	run(m, cmd)
+	if len(m.searchResults) != 1 || m.searchResults[0].ID != "p2" {
+		t.Fatalf("search results = %v", m.searchResults)
+	}
+	_, cmd = m.Update(press("enter"))
+	run(m, cmd)
+	if m.screen != types.ScreenProduct || m.currentProduct == nil || m.currentProduct.ID != "p2" {
+		t.Fatalf("screen = %v, want the product page for p2", m.screen)
+	}
+}
+
+// checkout opens the first product, adds it to the cart and fills in the
+// address form.
+func checkout(t *testing.T, m *Model, addr string) {
+	t.Helper()
+	_, cmd := m.Update(press("enter"))
+	run(m, cmd)
+	m.Update(press("a"))
+	if len(m.cart.Items) != 1 || m.cart.Items[0].Product.ID != "p1" {
+		t.Fatalf("cart = %+v", m.cart.Items)
+	}
+	m.Update(press("c"))
+	m.Update(press("enter"))
+	if m.screen != types.ScreenAddress {
+		t.Fatalf("screen = %v, want address", m.screen)
+	}
+	if addr == "" {
+		return
+	}
+	fields := []string{"Asha", "9876543210", "asha@example.com", addr, "", "Pune", "MH", "411001", "India"}
+	for _, f := range fields {
+		if f != "" {
+			m.Update(press(f))
+		}
+		m.Update(press("tab"))
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	m := loaded(t, nil, account.Identity{})
+	checkout(t, m, "1 MG Road")
+	m.Update(press("enter"))
+	if m.screen != types.ScreenCheckout {
+		t.Fatalf("screen = %v, want checkout: %v", m.screen, m.notification)
+	}
+	_, cmd := m.Update(press("y"))
+	run(m, cmd)
+	if m.screen != types.ScreenOrderSuccess || m.order == nil {
+		t.Fatalf("screen = %v, want order success", m.screen)
+	}
+	if got := m.order.TotalAmount; got != testProducts[0].SellingPrice {
+		t.Errorf("order total = %v, want %v", got, testProducts[0].SellingPrice)
+	}
+	m.Update(press("enter"))
+	if m.screen != types.ScreenHome || len(m.cart.Items) != 0 {
+		t.Errorf("after the order: screen = %v, %d items in cart", m.screen, len(m.cart.Items))
+	}
+}
@@ -3,10 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
-	"terminal-echoware/pkg/config"
 	"terminal-echoware/pkg/types"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func wrapText(text string, width int) string {
@@ -23,7 +23,7 @@ func wrapText(text string, width int) string {
 	currentLen := 0
 
 	for _, word := range words {
-		wordLen := len(word)
+		wordLen := ansi.StringWidth(word)
 		if currentLen+wordLen+1 > width && currentLen > 0 {
 			lines = append(lines, currentLine.String())
 			currentLine.Reset()
@@ -43,7 +43,7 @@ func wrapText(text string, width int) string {
 }
 
 func (m *Model) divider(w int) string {
-	return DividerStyle.Render(strings.Repeat("─", w))
+	return m.styles.Divider.Render(strings.Repeat("─", w))
 }
 
 func (m *Model) View() string {
@@ -73,16 +73,29 @@ func (m *Model) View() string {
 		header, content, footer = m.renderCheckout(w)
 	case types.ScreenOrderSuccess:
 		header, content, footer = m.renderOrderSuccess(w)
+	case types.ScreenOrders:
+		header, content, footer = m.renderOrders(w)
+	}
+
+	// The cart and product screens list their keys in a sidebar instead.
+	if m.helpOnTop() && m.screen != types.ScreenCart && m.screen != types.ScreenProduct {
+		if help := m.helpLine(m.screen); help != "" {
+			header = m.styles.Help.Render(help) + "\n" + header
+		}
+	}
+
+	if m.Degraded() {
+		header = m.styles.Banner.Width(w).Render(m.tr.T("banner.offline")) + "\n" + header
 	}
 
 	// Add notification if present
 	if m.notification != nil {
-		footer = RenderNotification(m.notification) + "\n" + footer
+		footer = m.styles.RenderNotification(m.notification) + "\n" + footer
 	}
 
 	// Add error if present
 	if m.err != nil {
-		footer = ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n" + footer
+		footer = m.styles.Error.Render(friendlyError(m.tr, m.err)) + "\n" + footer
 	}
 
 	// Calculate viewport height
@@ -217,7 +230,7 @@ func (m *Model) View() string {
 			}
 			// Truncate viewport line if too long
 			if lipgloss.Width(viewportLine) > contentWidth {
-				viewportLine = viewportLine[:contentWidth]
+				viewportLine = ansi.Truncate(viewportLine, contentWidth, "")
 			}
 			combinedLine := lipgloss.JoinHorizontal(lipgloss.Left, sidebarLine, " │ ", viewportLine)
 			combinedLines = append(combinedLines, combinedLine)
@@ -265,17 +278,17 @@ func (m *Model) View() string {
 }
 
 func (m *Model) renderLoading() string {
-	cfg := config.GetConfig()
+	cfg := m.cfg
 
 	var b strings.Builder
 	b.WriteString("\n")
-	b.WriteString(TitleStyle.Render(cfg.ShopName))
+	b.WriteString(m.styles.Title.Render(cfg.ShopName))
 	b.WriteString("\n\n")
 
 	// About Us
 	b.WriteString(m.divider(50))
 	b.WriteString("\n")
-	b.WriteString(TitleStyle.Render("ABOUT US"))
+	b.WriteString(m.styles.Title.Render(m.tr.T("loading.about")))
 	b.WriteString("\n")
 	b.WriteString(m.divider(50))
 	b.WriteString("\n\n")
@@ -285,7 +298,9 @@ func (m *Model) renderLoading() string {
 	b.WriteString("\n\n")
 
 	frame := string(LoadingFrames[m.loadingFrame%len(LoadingFrames)])
-	b.WriteString(LoadingStyle.Render(fmt.Sprintf("%s %s", frame, m.loadingMsg)))
+	b.WriteString(m.styles.Loading.Render(fmt.Sprintf("%s %s", frame, m.loadingMsg)))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Help.Render(m.tr.T("loading.cancel", m.keys.Back.Help().Key)))
 
 	// Center everything
 	return lipgloss.NewStyle().
@@ -299,14 +314,14 @@ func (m *Model) renderLoading() string {
 
 func (m *Model) renderHome(w int) (header, content, footer string) {
 	// HEADER
-	cfg := config.GetConfig()
+	cfg := m.cfg
 	var h strings.Builder
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
-	leftPart := TitleStyle.Render(cfg.ShopName)
-	rightPart := ""
+	leftPart := m.styles.Title.Render(cfg.ShopName)
+	rightPart := m.styles.Help.Render(m.whoami())
 	if m.cart.Count() > 0 {
-		rightPart = CartBadgeStyle.Render(fmt.Sprintf(" Cart(%d) ", m.cart.Count()))
+		rightPart += " " + m.styles.CartBadge.Render(" "+m.tr.T("cart.badge", m.cart.Count())+" ")
 	}
 	h.WriteString(m.headerRow(leftPart, rightPart, w))
 	h.WriteString("\n")
@@ -317,7 +332,9 @@ func (m *Model) renderHome(w int) (header, content, footer string) {
 	// CONTENT
 	var c strings.Builder
 	if len(m.homeProducts) == 0 {
-		c.WriteString("No products available.\n")
+		c.WriteString(m.tr.T("home.empty") + "\n\n")
+		c.WriteString(m.styles.Help.Render(m.tr.T("home.reload", m.keys.Select.Help().Key)))
+		c.WriteString("\n")
 	} else {
 		for i, p := range m.homeProducts {
 			c.WriteString(m.renderProductLine(p, i == m.cursor, w))
@@ -327,7 +344,7 @@ func (m *Model) renderHome(w int) (header, content, footer string) {
 	content = c.String()
 
 	// FOOTER
-	footer = m.renderFooter("↑/↓ Navigate   Enter View   S Search   C Cart   Q Quit", w)
+	footer = m.renderFooter(m.helpLine(types.ScreenHome), w)
 	return
 }
 
@@ -338,12 +355,12 @@ func (m *Model) renderSearch(w int) (header, content, footer string) {
 	var h strings.Builder
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
-	h.WriteString(m.headerRow("← Back", "SEARCH", w))
+	h.WriteString(m.headerRow(m.tr.T("back"), m.tr.T("search.title"), w))
 	h.WriteString("\n")
 	h.WriteString(m.divider(w))
 	h.WriteString("\n\n")
-	h.WriteString(fmt.Sprintf("Search: %s▌\n", m.searchQuery))
-	h.WriteString(HelpStyle.Render("Type to search • Tab to execute • Enter to select"))
+	h.WriteString(fmt.Sprintf("%s: %s▌\n", m.tr.T("search.prompt"), m.searchQuery))
+	h.WriteString(m.styles.Help.Render(m.tr.T("search.hint", m.keys.NextField.Help().Key, m.keys.Select.Help().Key)))
 	h.WriteString("\n\n")
 	header = h.String()
 
@@ -351,12 +368,12 @@ func (m *Model) renderSearch(w int) (header, content, footer string) {
 	var c strings.Builder
 	if len(m.searchResults) == 0 {
 		if m.searchQuery == "" {
-			c.WriteString("Start typing to search...\n")
+			c.WriteString(m.tr.T("search.start") + "\n")
 		} else {
-			c.WriteString("No results found.\n")
+			c.WriteString(m.tr.T("search.none") + "\n")
 		}
 	} else {
-		c.WriteString(fmt.Sprintf("Found %d results:\n\n", len(m.searchResults)))
+		c.WriteString(m.tr.Plural("search.found", len(m.searchResults)) + "\n\n")
 		for i, p := range m.searchResults {
 			c.WriteString(m.renderProductLine(p, i == m.cursor, w))
 			c.WriteString("\n")
@@ -365,7 +382,7 @@ func (m *Model) renderSearch(w int) (header, content, footer string) {
 	content = c.String()
 
 	// FOOTER
-	footer = m.renderFooter("↑/↓ Navigate   Enter Select   Esc Back", w)
+	footer = m.renderFooter(m.helpLine(types.ScreenSearch), w)
 	return
 }
 
@@ -373,28 +390,17 @@ func (m *Model) renderProductHotkeySidebar(height int) string {
 	sidebarWidth := 28
 	var sb strings.Builder
 	
-	sb.WriteString(SubtitleStyle.Render("KEYBOARD SHORTCUTS"))
+	sb.WriteString(m.styles.Subtitle.Render(m.tr.T("shortcuts")))
 	sb.WriteString("\n")
 	sb.WriteString(m.divider(sidebarWidth - 4))
 	sb.WriteString("\n\n")
 	
-	hotkeys := []struct {
-		key string
-		desc string
-	}{
-		{"Tab", "Next Option"},
-		{"Shift+Tab", "Prev Option"},
-		{"← / →", "Change Value"},
-		{"↑ / ↓", "Scroll"},
-		{"A / Enter", "Add to Cart"},
-		{"C", "View Cart"},
-		{"Esc / B", "Back"},
-		{"Q", "Quit"},
-	}
-	
-	for _, hk := range hotkeys {
-		keyPart := HelpStyle.Render(fmt.Sprintf("%-14s", hk.key))
-		descPart := NormalStyle.Render(hk.desc)
+	for _, h := range m.screenHelp(types.ScreenProduct) {
+		if !h.binding.Enabled() {
+			continue
+		}
+		keyPart := m.styles.Help.Render(padRight(h.binding.Help().Key, 14))
+		descPart := m.styles.Normal.Render(m.helpDesc(h))
 		sb.WriteString(fmt.Sprintf("%s %s\n", keyPart, descPart))
 	}
 	
@@ -408,7 +414,7 @@ func (m *Model) renderProductHotkeySidebar(height int) string {
 		Width(sidebarWidth).
 		Height(height).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorMuted).
+		BorderForeground(m.styles.Colors.Muted).
 		Padding(1, 1).
 		Render(sb.String())
 }
@@ -417,7 +423,7 @@ func (m *Model) renderProductHotkeySidebar(height int) string {
 
 func (m *Model) renderProduct(w int) (header, content, footer string) {
 	if m.currentProduct == nil {
-		return "", "Product not found.", ""
+		return "", m.tr.T("product.not_found"), ""
 	}
 	p := m.currentProduct
 
@@ -427,9 +433,9 @@ func (m *Model) renderProduct(w int) (header, content, footer string) {
 	h.WriteString("\n")
 	cartStr := ""
 	if m.cart.Count() > 0 {
-		cartStr = fmt.Sprintf("Cart(%d)", m.cart.Count())
+		cartStr = m.tr.T("cart.badge", m.cart.Count())
 	}
-	h.WriteString(m.headerRow("← Back", cartStr, w))
+	h.WriteString(m.headerRow(m.tr.T("back"), cartStr, w))
 	h.WriteString("\n")
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
@@ -439,14 +445,14 @@ func (m *Model) renderProduct(w int) (header, content, footer string) {
 	if p.Brand != "" {
 		titleLine = fmt.Sprintf("%s - %s", p.Brand, p.Name)
 	}
-	h.WriteString(TitleStyle.Render(titleLine))
+	h.WriteString(m.styles.Title.Render(titleLine))
 	h.WriteString("  ")
 	
-	priceStr := PriceStyle.Render(fmt.Sprintf("₹%.0f", p.SellingPrice))
-	if p.MRPPrice > p.SellingPrice {
-		discount := ((p.MRPPrice - p.SellingPrice) / p.MRPPrice) * 100
-		priceStr += " " + HelpStyle.Render(fmt.Sprintf("₹%.0f", p.MRPPrice))
-		priceStr += " " + SuccessStyle.Render(fmt.Sprintf("%.0f%% OFF", discount))
+	priceStr := m.styles.Price.Render(m.styles.FormatPrice(p.SellingPrice))
+	if p.MRPPrice.Cmp(p.SellingPrice) > 0 {
+		discount := p.MRPPrice.Sub(p.SellingPrice).Major() / p.MRPPrice.Major() * 100
+		priceStr += " " + m.styles.Help.Render(m.styles.FormatPrice(p.MRPPrice))
+		priceStr += " " + m.styles.Success.Render(m.tr.T("product.off", discount))
 	}
 	h.WriteString(priceStr)
 	h.WriteString("\n")
@@ -463,18 +469,18 @@ func (m *Model) renderProduct(w int) (header, content, footer string) {
 	var c strings.Builder
 	
 	// Description (compact)
-	c.WriteString(SubtitleStyle.Render("DESCRIPTION"))
+	c.WriteString(m.styles.Subtitle.Render(m.tr.T("product.description")))
 	c.WriteString("\n")
 	if p.ProductDescription != "" {
 		c.WriteString(wrapText(p.ProductDescription, contentWidth-2))
 	} else {
-		c.WriteString(HelpStyle.Render("No description available."))
+		c.WriteString(m.styles.Help.Render(m.tr.T("product.no_description")))
 	}
 	c.WriteString("\n\n")
 
 	// Features (compact)
 	if len(p.Features) > 0 {
-		c.WriteString(SubtitleStyle.Render("FEATURES"))
+		c.WriteString(m.styles.Subtitle.Render(m.tr.T("product.features")))
 		c.WriteString("\n")
 		for i, f := range p.Features {
 			c.WriteString(fmt.Sprintf("  • %s", f))
@@ -486,12 +492,12 @@ func (m *Model) renderProduct(w int) (header, content, footer string) {
 	}
 
 	// Options (compact, no box)
-	c.WriteString(SubtitleStyle.Render("OPTIONS"))
+	c.WriteString(m.styles.Subtitle.Render(m.tr.T("product.options")))
 	c.WriteString("\n")
 
 	// Quantity
 	qtyFocused := m.variantFocusIndex == 0
-	qtyLine := m.renderOptionLine("Quantity", fmt.Sprintf("%d", m.productQuantity), qtyFocused)
+	qtyLine := m.renderOptionLine(m.tr.T("product.quantity"), fmt.Sprintf("%d", m.productQuantity), qtyFocused)
 	c.WriteString(qtyLine)
 	c.WriteString("\n")
 
@@ -506,9 +512,9 @@ func (m *Model) renderProduct(w int) (header, content, footer string) {
 		var opts []string
 		for j, val := range variant.VariantValues {
 			if j == selectedIdx {
-				opts = append(opts, OptionValueSelectedStyle.Render(val.Label))
+				opts = append(opts, m.styles.OptionValueSelected.Render(val.Label))
 			} else {
-				opts = append(opts, OptionValueUnselectedStyle.Render(val.Label))
+				opts = append(opts, m.styles.OptionValueUnselected.Render(val.Label))
 			}
 		}
 		variantLine := m.renderOptionLine(variant.VariantName, strings.Join(opts, " "), focused)
@@ -519,10 +525,10 @@ func (m *Model) renderProduct(w int) (header, content, footer string) {
 
 	// Tags (compact)
 	if len(p.Tags) > 0 {
-		c.WriteString(SubtitleStyle.Render("TAGS"))
+		c.WriteString(m.styles.Subtitle.Render(m.tr.T("product.tags")))
 		c.WriteString("\n")
 		for _, tag := range p.Tags {
-			c.WriteString(BadgeStyle.Render(" #" + tag + " "))
+			c.WriteString(m.styles.Badge.Render(" #" + tag + " "))
 		}
 		c.WriteString("\n")
 	}
@@ -540,28 +546,17 @@ func (m *Model) renderHotkeySidebar(height int) string {
 	sidebarWidth := 28
 	var sb strings.Builder
 	
-	sb.WriteString(SubtitleStyle.Render("KEYBOARD SHORTCUTS"))
+	sb.WriteString(m.styles.Subtitle.Render(m.tr.T("shortcuts")))
 	sb.WriteString("\n")
 	sb.WriteString(m.divider(sidebarWidth - 4))
 	sb.WriteString("\n\n")
 	
-	hotkeys := []struct {
-		key string
-		desc string
-	}{
-		{"↑ / k", "Navigate Up"},
-		{"↓ / j", "Navigate Down"},
-		{"+ / =", "Increase Qty"},
-		{"- / _", "Decrease Qty"},
-		{"D / x", "Remove Item"},
-		{"Enter", "Checkout"},
-		{"Esc / b", "Back"},
-		{"Q", "Quit"},
-	}
-	
-	for _, hk := range hotkeys {
-		keyPart := HelpStyle.Render(fmt.Sprintf("%-12s", hk.key))
-		descPart := NormalStyle.Render(hk.desc)
+	for _, h := range m.screenHelp(types.ScreenCart) {
+		if !h.binding.Enabled() {
+			continue
+		}
+		keyPart := m.styles.Help.Render(padRight(h.binding.Help().Key, 12))
+		descPart := m.styles.Normal.Render(m.helpDesc(h))
 		sb.WriteString(fmt.Sprintf("%s %s\n", keyPart, descPart))
 	}
 	
@@ -576,7 +571,7 @@ func (m *Model) renderHotkeySidebar(height int) string {
 		Width(sidebarWidth).
 		Height(height).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorMuted).
+		BorderForeground(m.styles.Colors.Muted).
 		Padding(1, 1).
 		Render(sb.String())
 }
@@ -586,7 +581,7 @@ func (m *Model) renderCart(w int) (header, content, footer string) {
 	var h strings.Builder
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
-	h.WriteString(m.headerRow("← Back", "SHOPPING CART", w))
+	h.WriteString(m.headerRow(m.tr.T("back"), m.tr.T("cart.title"), w))
 	h.WriteString("\n")
 	h.WriteString(m.divider(w))
 	h.WriteString("\n\n")
@@ -600,8 +595,8 @@ func (m *Model) renderCart(w int) (header, content, footer string) {
 	
 	var c strings.Builder
 	if len(m.cart.Items) == 0 {
-		c.WriteString("Your cart is empty.\n\n")
-		c.WriteString(HelpStyle.Render("Press Esc to browse products"))
+		c.WriteString(m.tr.T("cart.empty") + "\n\n")
+		c.WriteString(m.styles.Help.Render(m.tr.T("cart.browse", m.keys.Back.Help().Key)))
 		c.WriteString("\n")
 	} else {
 		for i, item := range m.cart.Items {
@@ -612,7 +607,7 @@ func (m *Model) renderCart(w int) (header, content, footer string) {
 		c.WriteString("\n")
 		c.WriteString(m.divider(contentWidth))
 		c.WriteString("\n")
-		c.WriteString(TitleStyle.Render(fmt.Sprintf("Total: ₹%.0f", m.cart.Total())))
+		c.WriteString(m.styles.Title.Render(m.tr.T("total")+": "+m.styles.FormatPrice(m.cart.Total())))
 		c.WriteString("\n")
 	}
 	content = c.String()
@@ -626,11 +621,11 @@ func (m *Model) renderCart(w int) (header, content, footer string) {
 
 func (m *Model) renderAddress(w int) (header, content, footer string) {
 	// HEADER
-	cfg := config.GetConfig()
+	cfg := m.cfg
 	var h strings.Builder
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
-	h.WriteString(m.headerRow("← Back", cfg.ShopName, w))
+	h.WriteString(m.headerRow(m.tr.T("back"), cfg.ShopName, w))
 	h.WriteString("\n")
 	h.WriteString(m.divider(w))
 	h.WriteString("\n\n")
@@ -638,30 +633,34 @@ func (m *Model) renderAddress(w int) (header, content, footer string) {
 
 	// CONTENT
 	var c strings.Builder
-	c.WriteString(TitleStyle.Render("SHIPPING DETAILS"))
+	c.WriteString(m.styles.Title.Render(m.tr.T("address.title")))
+	if saved := m.savedAddresses(); m.cyclingSavedAddresses() && m.addressIndex < len(saved) && m.address == saved[m.addressIndex] {
+		c.WriteString("  ")
+		c.WriteString(m.styles.Help.Render(m.tr.T("address.saved", m.addressIndex+1, len(saved))))
+	}
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Full Name", m.address.FullName, m.cursor == 0, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.full_name"), m.address.FullName, m.cursor == 0, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Phone", m.address.Phone, m.cursor == 1, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.phone"), m.address.Phone, m.cursor == 1, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Email", m.address.Email, m.cursor == 2, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.email"), m.address.Email, m.cursor == 2, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Address 1", m.address.AddressLine1, m.cursor == 3, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.address_line1"), m.address.AddressLine1, m.cursor == 3, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Address 2", m.address.AddressLine2, m.cursor == 4, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.address_line2"), m.address.AddressLine2, m.cursor == 4, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("City", m.address.City, m.cursor == 5, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.city"), m.address.City, m.cursor == 5, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("State", m.address.State, m.cursor == 6, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.state"), m.address.State, m.cursor == 6, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Postal", m.address.PostalCode, m.cursor == 7, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.postal_code"), m.address.PostalCode, m.cursor == 7, w))
 	c.WriteString("\n\n")
-	c.WriteString(m.renderInputLine("Country", m.address.Country, m.cursor == 8, w))
+	c.WriteString(m.renderInputLine(m.tr.T("field.country"), m.address.Country, m.cursor == 8, w))
 	c.WriteString("\n")
 	content = c.String()
 
 	// FOOTER
-	footer = m.renderFooter("Tab/↓ Next   ↑ Previous   Enter Continue   Esc Back", w)
+	footer = m.renderFooter(m.helpLine(types.ScreenAddress), w)
 	return
 }
 
@@ -672,7 +671,7 @@ func (m *Model) renderCheckout(w int) (header, content, footer string) {
 	var h strings.Builder
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
-	h.WriteString(m.headerRow("← Back", "CHECKOUT", w))
+	h.WriteString(m.headerRow(m.tr.T("back"), m.tr.T("checkout.title"), w))
 	h.WriteString("\n")
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
@@ -684,7 +683,7 @@ func (m *Model) renderCheckout(w int) (header, content, footer string) {
 	
 	// LEFT: Order Summary (boxed)
 	var leftBox strings.Builder
-	leftBox.WriteString(SubtitleStyle.Render("ORDER SUMMARY"))
+	leftBox.WriteString(m.styles.Subtitle.Render(m.tr.T("checkout.summary")))
 	leftBox.WriteString("\n")
 	
 	// Items list
@@ -695,55 +694,55 @@ func (m *Model) renderCheckout(w int) (header, content, footer string) {
 	}
 	
 	for i, item := range itemsToShow {
-		total := item.Product.SellingPrice * float64(item.Quantity)
+		total := item.Total()
 		name := truncate(item.Product.Name, leftWidth-20)
 		leftBox.WriteString(fmt.Sprintf("  %d. %s\n", i+1, name))
-		leftBox.WriteString(fmt.Sprintf("     Qty: %d  %s\n", item.Quantity, PriceStyle.Render(fmt.Sprintf("₹%.0f", total))))
+		leftBox.WriteString(fmt.Sprintf("     %s  %s\n", m.tr.T("checkout.qty", item.Quantity), m.styles.Price.Render(m.styles.FormatPrice(total))))
 		if i < len(itemsToShow)-1 {
 			leftBox.WriteString("\n")
 		}
 	}
 	
 	if len(m.cart.Items) > maxItems {
-		leftBox.WriteString(fmt.Sprintf("\n  ... and %d more item(s)\n", len(m.cart.Items)-maxItems))
+		leftBox.WriteString("\n  " + m.tr.Plural("checkout.more", len(m.cart.Items)-maxItems) + "\n")
 	}
 	
 	leftBox.WriteString("\n")
 	leftBox.WriteString(m.divider(leftWidth - 4))
 	leftBox.WriteString("\n")
-	totalLine := fmt.Sprintf("  Total: %s", PriceStyle.Render(fmt.Sprintf("₹%.0f", m.cart.Total())))
-	leftBox.WriteString(TitleStyle.Render(totalLine))
+	totalLine := fmt.Sprintf("  %s: %s", m.tr.T("total"), m.styles.Price.Render(m.styles.FormatPrice(m.cart.Total())))
+	leftBox.WriteString(m.styles.Title.Render(totalLine))
 	
-	leftBoxRendered := BoxStyle.
+	leftBoxRendered := m.styles.Box.
 		Width(leftWidth - 2).
-		BorderForeground(ColorPrimary).
+		BorderForeground(m.styles.Colors.Primary).
 		Render(leftBox.String())
 	
 	// RIGHT: Shipping Address (boxed)
 	var rightBox strings.Builder
-	rightBox.WriteString(SubtitleStyle.Render("SHIPPING ADDRESS"))
+	rightBox.WriteString(m.styles.Subtitle.Render(m.tr.T("checkout.shipping")))
 	rightBox.WriteString("\n\n")
 	
-	rightBox.WriteString(fmt.Sprintf("  %s: %s\n", HelpStyle.Render("Name"), NormalStyle.Render(m.address.FullName)))
-	rightBox.WriteString(fmt.Sprintf("  %s: %s\n", HelpStyle.Render("Phone"), NormalStyle.Render(m.address.Phone)))
-	rightBox.WriteString(fmt.Sprintf("  %s: %s\n", HelpStyle.Render("Email"), NormalStyle.Render(m.address.Email)))
+	rightBox.WriteString(fmt.Sprintf("  %s: %s\n", m.styles.Help.Render(m.tr.T("field.name")), m.styles.Normal.Render(m.address.FullName)))
+	rightBox.WriteString(fmt.Sprintf("  %s: %s\n", m.styles.Help.Render(m.tr.T("field.phone")), m.styles.Normal.Render(m.address.Phone)))
+	rightBox.WriteString(fmt.Sprintf("  %s: %s\n", m.styles.Help.Render(m.tr.T("field.email")), m.styles.Normal.Render(m.address.Email)))
 	rightBox.WriteString("\n")
-	rightBox.WriteString(fmt.Sprintf("  %s:\n", HelpStyle.Render("Address")))
-	rightBox.WriteString(fmt.Sprintf("    %s\n", NormalStyle.Render(m.address.AddressLine1)))
+	rightBox.WriteString(fmt.Sprintf("  %s:\n", m.styles.Help.Render(m.tr.T("field.address"))))
+	rightBox.WriteString(fmt.Sprintf("    %s\n", m.styles.Normal.Render(m.address.AddressLine1)))
 	if strings.TrimSpace(m.address.AddressLine2) != "" {
-		rightBox.WriteString(fmt.Sprintf("    %s\n", NormalStyle.Render(m.address.AddressLine2)))
+		rightBox.WriteString(fmt.Sprintf("    %s\n", m.styles.Normal.Render(m.address.AddressLine2)))
 	}
 	rightBox.WriteString(fmt.Sprintf("    %s, %s %s\n", 
-		NormalStyle.Render(m.address.City),
-		NormalStyle.Render(m.address.State),
-		NormalStyle.Render(m.address.PostalCode)))
-	rightBox.WriteString(fmt.Sprintf("    %s\n", NormalStyle.Render(m.address.Country)))
+		m.styles.Normal.Render(m.address.City),
+		m.styles.Normal.Render(m.address.State),
+		m.styles.Normal.Render(m.address.PostalCode)))
+	rightBox.WriteString(fmt.Sprintf("    %s\n", m.styles.Normal.Render(m.address.Country)))
 	rightBox.WriteString("\n")
-	rightBox.WriteString(SuccessStyle.Render("  Enter/Y to confirm"))
+	rightBox.WriteString(m.styles.Success.Render("  " + m.tr.T("checkout.confirm", m.keys.Select.Help().Key+"/"+m.keys.Confirm.Help().Key)))
 	
-	rightBoxRendered := BoxStyle.
+	rightBoxRendered := m.styles.Box.
 		Width(rightWidth - 2).
-		BorderForeground(ColorSecondary).
+		BorderForeground(m.styles.Colors.Secondary).
 		Render(rightBox.String())
 	
 	// Combine left and right using lipgloss
@@ -752,7 +751,7 @@ func (m *Model) renderCheckout(w int) (header, content, footer string) {
 	content = combinedContent + "\n"
 
 	// FOOTER
-	footer = m.renderFooter("Enter/Y Confirm   N/Esc Back", w)
+	footer = m.renderFooter(m.helpLine(types.ScreenCheckout), w)
 	return
 }
 
@@ -760,7 +759,7 @@ func (m *Model) renderCheckout(w int) (header, content, footer string) {
 
 func (m *Model) renderOrderSuccess(w int) (header, content, footer string) {
 	// HEADER
-	cfg := config.GetConfig()
+	cfg := m.cfg
 	var h strings.Builder
 	h.WriteString(m.divider(w))
 	h.WriteString("\n")
@@ -778,28 +777,28 @@ func (m *Model) renderOrderSuccess(w int) (header, content, footer string) {
 	successMsg := lipgloss.NewStyle().
 		Width(w).
 		Align(lipgloss.Center).
-		Foreground(ColorAccent).
+		Foreground(m.styles.Colors.Accent).
 		Bold(true).
-		Render("✓ ORDER PLACED SUCCESSFULLY!")
+		Render(m.tr.T("order.placed"))
 	c.WriteString(successMsg)
 	c.WriteString("\n\n")
 	
 	// Order details box
 	if m.order != nil {
 		var orderBox strings.Builder
-		orderBox.WriteString(SubtitleStyle.Render("ORDER DETAILS"))
+		orderBox.WriteString(m.styles.Subtitle.Render(m.tr.T("order.details")))
 		orderBox.WriteString("\n")
 		orderBox.WriteString(m.divider(w - 4))
 		orderBox.WriteString("\n")
-		orderBox.WriteString(fmt.Sprintf("  %s: %s\n", HelpStyle.Render("Order ID"), TitleStyle.Render(m.order.ID)))
-		orderBox.WriteString(fmt.Sprintf("  %s: %s\n", HelpStyle.Render("Total"), PriceStyle.Render(fmt.Sprintf("₹%.0f", m.order.TotalAmount))))
-		orderBox.WriteString(fmt.Sprintf("  %s: %s\n", HelpStyle.Render("Status"), SuccessStyle.Render(string(m.order.Status.Type))))
+		orderBox.WriteString(fmt.Sprintf("  %s: %s\n", m.styles.Help.Render(m.tr.T("order.id")), m.styles.Title.Render(m.order.ID)))
+		orderBox.WriteString(fmt.Sprintf("  %s: %s\n", m.styles.Help.Render(m.tr.T("total")), m.styles.Price.Render(m.styles.FormatPrice(m.order.TotalAmount))))
+		orderBox.WriteString(fmt.Sprintf("  %s: %s\n", m.styles.Help.Render(m.tr.T("order.status")), m.styles.Success.Render(m.statusLabel(m.order.Status.Type))))
 		orderBox.WriteString(m.divider(w - 4))
 		
 		boxContent := orderBox.String()
-		orderBoxRendered := BoxStyle.
+		orderBoxRendered := m.styles.Box.
 			Width(w - 4).
-			BorderForeground(ColorAccent).
+			BorderForeground(m.styles.Colors.Accent).
 			Render(boxContent)
 		c.WriteString(orderBoxRendered)
 		c.WriteString("\n\n")
@@ -809,19 +808,74 @@ func (m *Model) renderOrderSuccess(w int) (header, content, footer string) {
 	thankYouMsg := lipgloss.NewStyle().
 		Width(w).
 		Align(lipgloss.Center).
-		Foreground(ColorSecondary).
-		Render("Thank you for your order!")
+		Foreground(m.styles.Colors.Secondary).
+		Render(m.tr.T("order.thanks"))
 	c.WriteString(thankYouMsg)
 	c.WriteString("\n")
 	content = c.String()
 
 	// FOOTER
-	footer = m.renderFooter("Press any key to continue", w)
+	footer = m.renderFooter(m.helpLine(types.ScreenOrderSuccess), w)
+	return
+}
+
+// ==================== ORDER HISTORY ====================
+
+func (m *Model) renderOrders(w int) (header, content, footer string) {
+	// HEADER
+	var h strings.Builder
+	h.WriteString(m.divider(w))
+	h.WriteString("\n")
+	h.WriteString(m.headerRow(m.tr.T("back"), m.tr.T("orders.title"), w))
+	h.WriteString("\n")
+	h.WriteString(m.divider(w))
+	h.WriteString("\n\n")
+	header = h.String()
+
+	// CONTENT
+	var c strings.Builder
+	customer, ok := m.customer()
+	switch {
+	case !ok:
+		c.WriteString(m.tr.T("orders.guest") + "\n")
+	case len(customer.Orders) == 0:
+		c.WriteString(m.tr.T("orders.empty") + "\n")
+	default:
+		for i, o := range customer.Orders {
+			cursor := "  "
+			style := m.styles.Normal
+			if i == m.cursor {
+				cursor = "▸ "
+				style = m.styles.Selected
+			}
+			line := fmt.Sprintf("%s%s  %s  %s  %s  %s",
+				cursor,
+				o.PlacedAt.Local().Format("02 Jan 2006"),
+				padRight(truncate(o.ID, 24), 24),
+				padLeft(m.tr.Plural("orders.items", o.Items), 10),
+				m.styles.Price.Render(padLeft(m.styles.FormatPrice(o.Total), 14)),
+				m.statusLabel(o.Status))
+			c.WriteString(style.Render(line))
+			c.WriteString("\n")
+		}
+	}
+	content = c.String()
+
+	// FOOTER
+	footer = m.renderFooter(m.helpLine(types.ScreenOrders), w)
 	return
 }
 
 // ==================== HELPER RENDERERS ====================
 
+// whoami names the session's customer for the header.
+func (m *Model) whoami() string {
+	if m.identity.Guest() {
+		return m.tr.T("account.guest")
+	}
+	return m.tr.T("account.signed_in", m.identity.User)
+}
+
 func (m *Model) headerRow(left, right string, w int) string {
 	leftLen := lipgloss.Width(left)
 	rightLen := lipgloss.Width(right)
@@ -832,11 +886,22 @@ func (m *Model) headerRow(left, right string, w int) string {
 	return left + strings.Repeat(" ", space) + right
 }
 
+// helpOnTop reports whether controls.help_position puts the help line above
+// the header.
+func (m *Model) helpOnTop() bool {
+	return m.cfg.Controls.HelpPosition == "top"
+}
+
+// renderFooter closes the screen with its help line, unless help is off or
+// drawn above the header instead (see View).
 func (m *Model) renderFooter(helpText string, w int) string {
+	if helpText == "" || m.helpOnTop() {
+		return m.divider(w)
+	}
 	var f strings.Builder
 	f.WriteString(m.divider(w))
 	f.WriteString("\n")
-	f.WriteString(HelpStyle.Render(helpText))
+	f.WriteString(m.styles.Help.Render(helpText))
 	f.WriteString("\n")
 	f.WriteString(m.divider(w))
 	return f.String()
@@ -844,10 +909,10 @@ func (m *Model) renderFooter(helpText string, w int) string {
 
 func (m *Model) renderProductLine(p types.Product, selected bool, w int) string {
 	cursor := "  "
-	style := NormalStyle
+	style := m.styles.Normal
 	if selected {
 		cursor = "▸ "
-		style = SelectedStyle
+		style = m.styles.Selected
 	}
 
 	nameW := w - 30
@@ -855,18 +920,18 @@ func (m *Model) renderProductLine(p types.Product, selected bool, w int) string
 		nameW = 20
 	}
 	name := truncate(p.Name, nameW)
-	price := fmt.Sprintf("₹%.0f", p.SellingPrice)
+	price := m.styles.FormatPrice(p.SellingPrice)
 
-	line := fmt.Sprintf("%s%-*s  %s", cursor, nameW, name, PriceStyle.Render(price))
+	line := fmt.Sprintf("%s%s  %s", cursor, padRight(name, nameW), m.styles.Price.Render(price))
 	return style.Render(line)
 }
 
 func (m *Model) renderCartLine(item types.CartItem, selected bool, w int) string {
 	cursor := "  "
-	style := NormalStyle
+	style := m.styles.Normal
 	if selected {
 		cursor = "▸ "
-		style = SelectedStyle
+		style = m.styles.Selected
 	}
 
 	nameW := w - 35
@@ -874,35 +939,44 @@ func (m *Model) renderCartLine(item types.CartItem, selected bool, w int) string
 		nameW = 15
 	}
 	name := truncate(item.Product.Name, nameW)
-	total := item.Product.SellingPrice * float64(item.Quantity)
+	total := item.Total()
 
 	qtyStr := fmt.Sprintf("[-] %2d [+]", item.Quantity)
 	if selected {
-		qtyStr = SuccessStyle.Render(qtyStr)
+		qtyStr = m.styles.Success.Render(qtyStr)
 	} else {
-		qtyStr = HelpStyle.Render(qtyStr)
+		qtyStr = m.styles.Help.Render(qtyStr)
 	}
 
-	line := fmt.Sprintf("%s%-*s  %s  %s", cursor, nameW, name, qtyStr, PriceStyle.Render(fmt.Sprintf("₹%.0f", total)))
+	line := fmt.Sprintf("%s%s  %s  %s", cursor, padRight(name, nameW), qtyStr, m.styles.Price.Render(m.styles.FormatPrice(total)))
 	return style.Render(line)
 }
 
 func (m *Model) renderOptionLine(label, value string, focused bool) string {
-	style := NormalStyle
+	style := m.styles.Normal
 	prefix := "  "
 	if focused {
-		style = SelectedStyle
+		style = m.styles.Selected
 		prefix = "▸ "
 	}
-	return style.Render(fmt.Sprintf("%s%-12s: %s", prefix, label, value))
+	return style.Render(fmt.Sprintf("%s%s: %s", prefix, padRight(label, 12), value))
+}
+
+// statusLabel names an order status in the session's language; statuses
+// the client does not know are shown as sent.
+func (m *Model) statusLabel(status types.OrderStatusType) string {
+	if !status.Valid() {
+		return string(status)
+	}
+	return m.tr.T("status." + string(status))
 }
 
 func (m *Model) renderInputLine(label, value string, focused bool, w int) string {
 	cursor := ""
-	style := NormalStyle
+	style := m.styles.Normal
 	if focused {
 		cursor = "▌"
-		style = SelectedStyle
+		style = m.styles.Selected
 	}
-	return style.Width(w - 4).Render(fmt.Sprintf("%-10s: %s%s", label, value, cursor))
+	return style.Width(w - 4).Render(fmt.Sprintf("%s: %s%s", padRight(label, 10), value, cursor))
 }
@@ -0,0 +1,35 @@
+package config
+
+// Actions the TUI dispatches on; these are the keys of
+// ControlsConfig.KeyBindings and the values of CustomBindings.
+const (
+	ActionNavigateUp   = "navigate_up"
+	ActionNavigateDown = "navigate_down"
+	ActionSelect       = "select"
+	ActionSearch       = "search"
+	ActionCart         = "cart"
+	ActionAddToCart    = "add_to_cart"
+	ActionDelete       = "delete"
+	ActionBack         = "back"
+	ActionQuit         = "quit"
+	ActionNextField    = "tab"
+	ActionPrevField    = "prev_field"
+	ActionOptionLeft   = "option_left"
+	ActionOptionRight  = "option_right"
+	ActionIncrease     = "increase"
+	ActionDecrease     = "decrease"
+	ActionConfirm      = "confirm"
+	ActionCancel       = "cancel"
+	ActionTheme        = "theme"
+	ActionLanguage     = "language"
+	ActionOrders       = "orders"
+)
+
+// Actions lists every action name.
+var Actions = []string{
+	ActionNavigateUp, ActionNavigateDown, ActionSelect, ActionSearch,
+	ActionCart, ActionAddToCart, ActionDelete, ActionBack, ActionQuit,
+	ActionNextField, ActionPrevField, ActionOptionLeft, ActionOptionRight,
+	ActionIncrease, ActionDecrease, ActionConfirm, ActionCancel, ActionTheme,
+	ActionLanguage, ActionOrders,
+}
@@ -1,45 +1,168 @@
 package config
 
-import "strings"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
+// KeyBinding binds an action to Keys, written the way bubbletea names them
+// ("up", "k", "ctrl+t", " " for space). Key is the label shown in help; when
+// empty it is derived from Keys. A binding with no Keys is disabled.
 type KeyBinding struct {
-	Key         string
-	Description string
+	Key         string   `yaml:"key" json:"key" toml:"key"`
+	Keys        []string `yaml:"keys" json:"keys" toml:"keys"`
+	Description string   `yaml:"description" json:"description" toml:"description"`
 }
 
+// Help returns the label shown for b in footers and help.
+func (b KeyBinding) Help() string {
+	if b.Key != "" {
+		return b.Key
+	}
+	labels := make([]string, len(b.Keys))
+	for i, k := range b.Keys {
+		labels[i] = keyLabel(k)
+	}
+	return strings.Join(labels, "/")
+}
+
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "Space",
+}
+
+func keyLabel(k string) string {
+	if l, ok := keyLabels[k]; ok {
+		return l
+	}
+	parts := strings.Split(k, "+")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// ControlsConfig holds the key bindings, keyed by action name (see Actions).
+// CustomBindings adds extra keys to actions, mapping key to action, e.g.
+// "ctrl+f": "search".
 type ControlsConfig struct {
-	ShowHelp      bool
-	HelpPosition  string
-	KeyBindings   map[string]KeyBinding
-	CustomBindings map[string]string
+	ShowHelp       bool                  `yaml:"show_help" json:"show_help" toml:"show_help"`
+	HelpPosition   string                `yaml:"help_position" json:"help_position" toml:"help_position"`
+	KeyBindings    map[string]KeyBinding `yaml:"key_bindings" json:"key_bindings" toml:"key_bindings"`
+	CustomBindings map[string]string     `yaml:"custom_bindings" json:"custom_bindings" toml:"custom_bindings"`
 }
 
+// AppConfig is loaded once at startup and shared by every session, so it
+// must not be modified afterwards; derive per-session variants with
+// WithOverlay.
 type AppConfig struct {
-	APIBaseURL         string
-	SSHPort            string
-	ShowControls       bool
-	ShopName           string
-	CompanyName        string
-	CompanyDescription string
-	Controls           ControlsConfig
-	Theme              ThemeConfig
+	APIBaseURL         string         `yaml:"api_base_url" json:"api_base_url" toml:"api_base_url"`
+	SSHPort            string         `yaml:"ssh_port" json:"ssh_port" toml:"ssh_port"`
+	ShowControls       bool           `yaml:"show_controls" json:"show_controls" toml:"show_controls"`
+	Language           string         `yaml:"language" json:"language" toml:"language"`
+	ShopName           string         `yaml:"shop_name" json:"shop_name" toml:"shop_name"`
+	CompanyName        string         `yaml:"company_name" json:"company_name" toml:"company_name"`
+	CompanyDescription string         `yaml:"company_description" json:"company_description" toml:"company_description"`
+	Controls           ControlsConfig `yaml:"controls" json:"controls" toml:"controls"`
+	Theme              ThemeConfig    `yaml:"theme" json:"theme" toml:"theme"`
+	Currency           CurrencyConfig `yaml:"currency" json:"currency" toml:"currency"`
+	Audit              AuditConfig    `yaml:"audit" json:"audit" toml:"audit"`
+	Accounts           AccountsConfig `yaml:"accounts" json:"accounts" toml:"accounts"`
+	Reload             ReloadConfig   `yaml:"reload" json:"reload" toml:"reload"`
+	// Storefronts are extra shops served by the same process, keyed by
+	// name. Sessions that match none of them get the top-level shop.
+	Storefronts map[string]StorefrontConfig `yaml:"storefronts" json:"storefronts" toml:"storefronts"`
 }
 
-type ThemeConfig struct {
-	PrimaryColor   string
-	SecondaryColor string
-	AccentColor    string
-	ErrorColor     string
-	SuccessColor   string
+// Session reload policies.
+const (
+	SessionsNextScreen = "next_screen" // adopt a reloaded config on the next screen change
+	SessionsSnapshot   = "snapshot"    // keep the config the session started with
+)
+
+// ReloadConfig controls hot reloading. SIGHUP always re-reads the config
+// file; Watch additionally polls it every Interval.
+type ReloadConfig struct {
+	Watch    bool   `yaml:"watch" json:"watch" toml:"watch"`
+	Interval string `yaml:"interval" json:"interval" toml:"interval"` // e.g. "5s"
+	Sessions string `yaml:"sessions" json:"sessions" toml:"sessions"`
+}
+
+// PollInterval parses Interval, defaulting to five seconds.
+func (r ReloadConfig) PollInterval() (time.Duration, error) {
+	if r.Interval == "" {
+		return 5 * time.Second, nil
+	}
+	d, err := time.ParseDuration(r.Interval)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid reload interval %q", r.Interval)
+	}
+	return d, nil
+}
+
+// AuditConfig controls the backend audit log. An empty Path disables it.
+type AuditConfig struct {
+	Path       string `yaml:"path" json:"path" toml:"path"`
+	MaxSizeMB  int    `yaml:"max_size_mb" json:"max_size_mb" toml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups" json:"max_backups" toml:"max_backups"`
+	MaxAgeDays int    `yaml:"max_age_days" json:"max_age_days" toml:"max_age_days"`
+	FileMode   string `yaml:"file_mode" json:"file_mode" toml:"file_mode"` // octal, e.g. "0600"
+}
+
+// Mode parses FileMode, defaulting to owner read/write only.
+func (a AuditConfig) Mode() (os.FileMode, error) {
+	if a.FileMode == "" {
+		return 0o600, nil
+	}
+	mode, err := strconv.ParseUint(a.FileMode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid audit file mode %q", a.FileMode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// AccountsConfig controls customer accounts, which are keyed by the SSH
+// public key a customer signs in with. An empty Path keeps accounts in
+// memory only, so they are lost on restart.
+type AccountsConfig struct {
+	Path string `yaml:"path" json:"path" toml:"path"`
+	// AllowGuests lets clients without a key in through keyboard-interactive
+	// auth; guests can shop but nothing is saved for them.
+	AllowGuests bool `yaml:"allow_guests" json:"allow_guests" toml:"allow_guests"`
 }
 
-var GlobalConfig *AppConfig
+// ThemeConfig picks a built-in theme by Name and optionally overrides any of
+// its colors. Colors are ANSI 256 indexes ("205") or hex ("#ff79c6").
+type ThemeConfig struct {
+	Name            string `yaml:"name" json:"name" toml:"name"`
+	PrimaryColor    string `yaml:"primary_color" json:"primary_color" toml:"primary_color"`
+	SecondaryColor  string `yaml:"secondary_color" json:"secondary_color" toml:"secondary_color"`
+	AccentColor     string `yaml:"accent_color" json:"accent_color" toml:"accent_color"`
+	WarningColor    string `yaml:"warning_color" json:"warning_color" toml:"warning_color"`
+	ErrorColor      string `yaml:"error_color" json:"error_color" toml:"error_color"`
+	SuccessColor    string `yaml:"success_color" json:"success_color" toml:"success_color"`
+	MutedColor      string `yaml:"muted_color" json:"muted_color" toml:"muted_color"`
+	BackgroundColor string `yaml:"background_color" json:"background_color" toml:"background_color"`
+	HighlightColor  string `yaml:"highlight_color" json:"highlight_color" toml:"highlight_color"`
+	ForegroundColor string `yaml:"foreground_color" json:"foreground_color" toml:"foreground_color"`
+}
 
-func InitConfig() {
-	GlobalConfig = &AppConfig{
+// Default returns the built-in configuration that files and environment
+// variables are layered over.
+func Default() *AppConfig {
+	return &AppConfig{
 		APIBaseURL:         "https://lowkey-backend-omega.vercel.app",
 		SSHPort:            "2222",
 		ShowControls:       true,
+		Language:           "en",
 		ShopName:           "Nrix7 Shop",
 		CompanyName:        "Nrix7 E-Commerce",
 		CompanyDescription: "Welcome to Nrix7 - Your trusted destination for quality products at unbeatable prices. We bring you the latest trends in fashion, electronics, home essentials, and more. Shop with confidence through our secure terminal interface. Fast shipping, easy returns, and 24/7 customer support.",
@@ -47,103 +170,59 @@ func InitConfig() {
 			ShowHelp:     true,
 			HelpPosition: "bottom",
 			KeyBindings: map[string]KeyBinding{
-				"navigate_up":    {Key: "↑/k", Description: "Navigate up"},
-				"navigate_down":  {Key: "↓/j", Description: "Navigate down"},
-				"select":         {Key: "Enter", Description: "Select/Confirm"},
-				"search":         {Key: "S", Description: "Search"},
-				"cart":           {Key: "C", Description: "View Cart"},
-				"add_to_cart":    {Key: "A", Description: "Add to Cart"},
-				"delete":         {Key: "D", Description: "Delete"},
-				"back":            {Key: "Esc/B", Description: "Back"},
-				"quit":            {Key: "Q/Ctrl+C", Description: "Quit"},
-				"tab":             {Key: "Tab", Description: "Switch field"},
+				"navigate_up":   {Key: "↑/k", Keys: []string{"up", "k"}, Description: "Navigate up"},
+				"navigate_down": {Key: "↓/j", Keys: []string{"down", "j"}, Description: "Navigate down"},
+				"select":        {Key: "Enter", Keys: []string{"enter", " "}, Description: "Select/Confirm"},
+				"search":        {Key: "S", Keys: []string{"s", "/"}, Description: "Search"},
+				"cart":          {Key: "C", Keys: []string{"c"}, Description: "View Cart"},
+				"add_to_cart":   {Key: "A", Keys: []string{"a"}, Description: "Add to Cart"},
+				"delete":        {Key: "D", Keys: []string{"d", "x"}, Description: "Delete"},
+				"back":          {Key: "Esc/B", Keys: []string{"esc", "b"}, Description: "Back"},
+				"quit":          {Key: "Q/Ctrl+C", Keys: []string{"q", "ctrl+c"}, Description: "Quit"},
+				"tab":           {Key: "Tab", Keys: []string{"tab"}, Description: "Switch field"},
+				"prev_field":    {Key: "Shift+Tab", Keys: []string{"shift+tab"}, Description: "Previous field"},
+				"option_left":   {Key: "←/h", Keys: []string{"left", "h"}, Description: "Previous option"},
+				"option_right":  {Key: "→/l", Keys: []string{"right", "l"}, Description: "Next option"},
+				"increase":      {Key: "+", Keys: []string{"+", "="}, Description: "Increase quantity"},
+				"decrease":      {Key: "-", Keys: []string{"-", "_"}, Description: "Decrease quantity"},
+				"confirm":       {Key: "Y", Keys: []string{"y"}, Description: "Place order"},
+				"cancel":        {Key: "N", Keys: []string{"n"}, Description: "Go back"},
+				"theme":         {Key: "Ctrl+T", Keys: []string{"ctrl+t"}, Description: "Switch theme"},
+				"language":      {Key: "Ctrl+L", Keys: []string{"ctrl+l"}, Description: "Switch language"},
+				"orders":        {Key: "O", Keys: []string{"o"}, Description: "Order history"},
 			},
 			CustomBindings: make(map[string]string),
 		},
 		Theme: ThemeConfig{
-			PrimaryColor:   "205",
-			SecondaryColor: "117",
-			AccentColor:    "82",
-			ErrorColor:     "196",
-			SuccessColor:   "46",
+			Name: ThemeDark,
+		},
+		Currency: CurrencyConfig{
+			Symbol:   "₹",
+			Code:     "INR",
+			Grouping: GroupingIndian,
+			Decimals: DecimalsAlways,
+		},
+		Audit: AuditConfig{
+			Path:       "logs/audit.jsonl",
+			MaxSizeMB:  10,
+			MaxBackups: 5,
+			MaxAgeDays: 30,
+			FileMode:   "0600",
+		},
+		Accounts: AccountsConfig{
+			Path:        "data/accounts.json",
+			AllowGuests: true,
+		},
+		Reload: ReloadConfig{
+			Interval: "5s",
+			Sessions: SessionsNextScreen,
 		},
 	}
 }
 
-func GetConfig() *AppConfig {
-	if GlobalConfig == nil {
-		InitConfig()
-	}
-	return GlobalConfig
-}
-
 func (c *AppConfig) GetKeyBinding(action string) KeyBinding {
 	if binding, ok := c.Controls.KeyBindings[action]; ok {
 		return binding
 	}
 	return KeyBinding{Key: "", Description: ""}
 }
-
-func (c *AppConfig) GetHelpText(screen string) string {
-	if !c.ShowControls || !c.Controls.ShowHelp {
-		return ""
-	}
-
-	var bindings []string
-	switch screen {
-	case "home":
-		bindings = []string{
-			c.Controls.KeyBindings["navigate_up"].Key + ": " + c.Controls.KeyBindings["navigate_up"].Description,
-			c.Controls.KeyBindings["navigate_down"].Key + ": " + c.Controls.KeyBindings["navigate_down"].Description,
-			c.Controls.KeyBindings["select"].Key + ": View",
-			c.Controls.KeyBindings["search"].Key + ": " + c.Controls.KeyBindings["search"].Description,
-			c.Controls.KeyBindings["cart"].Key + ": " + c.Controls.KeyBindings["cart"].Description,
-			c.Controls.KeyBindings["quit"].Key + ": " + c.Controls.KeyBindings["quit"].Description,
-		}
-	case "search":
-		bindings = []string{
-			"Type: Search",
-			c.Controls.KeyBindings["navigate_up"].Key + ": " + c.Controls.KeyBindings["navigate_up"].Description,
-			c.Controls.KeyBindings["navigate_down"].Key + ": " + c.Controls.KeyBindings["navigate_down"].Description,
-			c.Controls.KeyBindings["select"].Key + ": Search",
-			c.Controls.KeyBindings["back"].Key + ": " + c.Controls.KeyBindings["back"].Description,
-		}
-	case "product":
-		bindings = []string{
-			c.Controls.KeyBindings["add_to_cart"].Key + ": " + c.Controls.KeyBindings["add_to_cart"].Description,
-			c.Controls.KeyBindings["cart"].Key + ": " + c.Controls.KeyBindings["cart"].Description,
-			c.Controls.KeyBindings["back"].Key + ": " + c.Controls.KeyBindings["back"].Description,
-			c.Controls.KeyBindings["quit"].Key + ": " + c.Controls.KeyBindings["quit"].Description,
-		}
-	case "cart":
-		bindings = []string{
-			c.Controls.KeyBindings["navigate_up"].Key + ": " + c.Controls.KeyBindings["navigate_up"].Description,
-			c.Controls.KeyBindings["navigate_down"].Key + ": " + c.Controls.KeyBindings["navigate_down"].Description,
-			c.Controls.KeyBindings["delete"].Key + ": " + c.Controls.KeyBindings["delete"].Description,
-			c.Controls.KeyBindings["select"].Key + ": Checkout",
-			c.Controls.KeyBindings["back"].Key + ": " + c.Controls.KeyBindings["back"].Description,
-		}
-	case "address":
-		bindings = []string{
-			c.Controls.KeyBindings["tab"].Key + ": " + c.Controls.KeyBindings["tab"].Description,
-			c.Controls.KeyBindings["select"].Key + ": Continue",
-			c.Controls.KeyBindings["back"].Key + ": " + c.Controls.KeyBindings["back"].Description,
-		}
-	case "checkout":
-		bindings = []string{
-			c.Controls.KeyBindings["select"].Key + "/Y: Place Order",
-			"N: Back",
-			c.Controls.KeyBindings["back"].Key + ": " + c.Controls.KeyBindings["back"].Description,
-		}
-	case "order_success":
-		bindings = []string{
-			c.Controls.KeyBindings["select"].Key + ": Continue Shopping",
-		}
-	default:
-		bindings = []string{
-			c.Controls.KeyBindings["quit"].Key + ": " + c.Controls.KeyBindings["quit"].Description,
-		}
-	}
-
-	return strings.Join(bindings, " | ")
-}
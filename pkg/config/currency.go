@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"terminal-echoware/pkg/types"
+)
+
+// Grouping styles for the integer part of an amount.
+const (
+	GroupingIndian  = "indian"  // 1,25,00,000
+	GroupingWestern = "western" // 12,500,000
+	GroupingNone    = "none"    // 12500000
+)
+
+// Decimals policies for the minor-unit part of an amount.
+const (
+	DecimalsAlways = "always" // 1,250.00
+	DecimalsAuto   = "auto"   // 1,250 but 1,250.50
+	DecimalsNever  = "never"  // 1,250; rounded half away from zero
+)
+
+// CurrencyConfig controls how prices are rendered.
+type CurrencyConfig struct {
+	Symbol           string `yaml:"symbol" json:"symbol" toml:"symbol"`                   // e.g. "₹"; when empty, Code is shown instead
+	Code             string `yaml:"code" json:"code" toml:"code"`                         // ISO 4217 code, e.g. "INR"
+	SymbolAfter      bool   `yaml:"symbol_after" json:"symbol_after" toml:"symbol_after"` // "1.250,00 €" rather than "€1.250,00"
+	Grouping         string `yaml:"grouping" json:"grouping" toml:"grouping"`
+	Decimals         string `yaml:"decimals" json:"decimals" toml:"decimals"`
+	GroupSeparator   string `yaml:"group_separator" json:"group_separator" toml:"group_separator"`
+	DecimalSeparator string `yaml:"decimal_separator" json:"decimal_separator" toml:"decimal_separator"`
+}
+
+// Format renders amount according to c, e.g. "₹1,25,000.00".
+func (c CurrencyConfig) Format(amount types.Money) string {
+	digits := amount.Digits()
+	minor := amount.Minor
+	neg := minor < 0
+	if neg {
+		minor = -minor
+	}
+
+	scale := int64(1)
+	for range digits {
+		scale *= 10
+	}
+	whole, frac := minor/scale, minor%scale
+	showFrac := digits > 0
+	switch c.Decimals {
+	case DecimalsNever:
+		if frac*2 >= scale {
+			whole++
+		}
+		showFrac = false
+	case DecimalsAuto:
+		showFrac = showFrac && frac != 0
+	}
+
+	var b strings.Builder
+	b.WriteString(c.group(whole))
+	if showFrac {
+		sep := c.DecimalSeparator
+		if sep == "" {
+			sep = "."
+		}
+		b.WriteString(sep)
+		fmt.Fprintf(&b, "%0*d", digits, frac)
+	}
+	number := b.String()
+
+	symbol := c.Symbol
+	if symbol == "" {
+		symbol = c.Code
+		if symbol == "" {
+			symbol = amount.Code()
+		}
+		symbol += " "
+	}
+	if c.SymbolAfter {
+		number += " " + strings.TrimSpace(symbol)
+	} else {
+		number = symbol + number
+	}
+	if neg && (whole != 0 || (showFrac && frac != 0)) {
+		number = "-" + number
+	}
+	return number
+}
+
+// group inserts the group separator into the integer part: the last three
+// digits first, then every two (Indian) or every three (western) digits.
+func (c CurrencyConfig) group(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	if c.Grouping == GroupingNone || len(digits) <= 3 {
+		return digits
+	}
+	sep := c.GroupSeparator
+	if sep == "" {
+		sep = ","
+	}
+	size := 3
+	if c.Grouping == GroupingIndian {
+		size = 2
+	}
+
+	head, tail := digits[:len(digits)-3], digits[len(digits)-3:]
+	var parts []string
+	for len(head) > size {
+		parts = append([]string{head[len(head)-size:]}, parts...)
+		head = head[:len(head)-size]
+	}
+	parts = append([]string{head}, parts...)
+	return strings.Join(append(parts, tail), sep)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"terminal-echoware/pkg/types"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	inr := CurrencyConfig{Symbol: "₹", Code: "INR", Grouping: GroupingIndian, Decimals: DecimalsAlways}
+	eur := CurrencyConfig{Symbol: "€", Code: "EUR", SymbolAfter: true, Grouping: GroupingWestern,
+		Decimals: DecimalsAlways, GroupSeparator: ".", DecimalSeparator: ","}
+	with := func(c CurrencyConfig, fn func(*CurrencyConfig)) CurrencyConfig {
+		fn(&c)
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		cfg    CurrencyConfig
+		amount types.Money
+		want   string
+	}{
+		{"indian", inr, types.NewMoney(1250000000, ""), "₹1,25,00,000.00"},
+		{"indian thousands", inr, types.NewMoney(125000, ""), "₹1,250.00"},
+		{"indian small", inr, types.NewMoney(99900, ""), "₹999.00"},
+		{"western", with(inr, func(c *CurrencyConfig) { c.Grouping = GroupingWestern }), types.NewMoney(1250000000, ""), "₹12,500,000.00"},
+		{"default grouping is western", with(inr, func(c *CurrencyConfig) { c.Grouping = "" }), types.NewMoney(123456789, ""), "₹1,234,567.89"},
+		{"no grouping", with(inr, func(c *CurrencyConfig) { c.Grouping = GroupingNone }), types.NewMoney(1250000000, ""), "₹12500000.00"},
+		{"auto whole", with(inr, func(c *CurrencyConfig) { c.Decimals = DecimalsAuto }), types.NewMoney(125000, ""), "₹1,250"},
+		{"auto fraction", with(inr, func(c *CurrencyConfig) { c.Decimals = DecimalsAuto }), types.NewMoney(125050, ""), "₹1,250.50"},
+		{"never rounds up", with(inr, func(c *CurrencyConfig) { c.Decimals = DecimalsNever }), types.NewMoney(125050, ""), "₹1,251"},
+		{"never rounds down", with(inr, func(c *CurrencyConfig) { c.Decimals = DecimalsNever }), types.NewMoney(125049, ""), "₹1,250"},
+		{"separators and symbol after", eur, types.NewMoney(125000050, "EUR"), "1.250.000,50 €"},
+		{"code when no symbol", CurrencyConfig{Code: "USD"}, types.NewMoney(199, "USD"), "USD 1.99"},
+		{"amount's code when neither", CurrencyConfig{}, types.NewMoney(199, ""), "INR 1.99"},
+		{"code after", CurrencyConfig{Code: "USD", SymbolAfter: true}, types.NewMoney(199, "USD"), "1.99 USD"},
+		{"zero-decimal currency", CurrencyConfig{Symbol: "¥"}, types.NewMoney(1500000, "JPY"), "¥1,500,000"},
+		{"three-decimal currency", CurrencyConfig{Code: "KWD"}, types.NewMoney(1234, "KWD"), "KWD 1.234"},
+		{"negative", inr, types.NewMoney(-125000, ""), "-₹1,250.00"},
+		{"negative rounds to zero", with(inr, func(c *CurrencyConfig) { c.Decimals = DecimalsNever }), types.NewMoney(-49, ""), "₹0"},
+		{"negative fraction", inr, types.NewMoney(-5, ""), "-₹0.05"},
+		{"zero", inr, types.Money{}, "₹0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.Format(tt.amount); got != tt.want {
+				t.Errorf("Format(%v) = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
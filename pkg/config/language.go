@@ -0,0 +1,26 @@
+package config
+
+import "strings"
+
+// Languages lists the language codes the TUI has message catalogs for, in
+// switcher order. Language settings are checked against it.
+var Languages = []string{"en", "hi"}
+
+// MatchLanguage maps a language setting to one of Languages. It accepts
+// plain codes ("hi") as well as POSIX locales ("hi_IN.UTF-8") and BCP 47
+// tags ("en-GB").
+func MatchLanguage(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if i := strings.IndexAny(tag, "_-"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, lang := range Languages {
+		if tag == lang {
+			return lang, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestMatchLanguage(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{"hi", "hi", true},
+		{"hi_IN.UTF-8", "hi", true},
+		{"en-GB", "en", true},
+		{" EN ", "en", true},
+		{"C.UTF-8", "", false},
+		{"fr_FR", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := MatchLanguage(tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("MatchLanguage(%q) = %q, %v; want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
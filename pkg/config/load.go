@@ -0,0 +1,246 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variables that override config
+// fields. Names follow the file keys: theme.primary_color is
+// NRIX_THEME_PRIMARY_COLOR, controls.key_bindings.search.key is
+// NRIX_CONTROLS_KEY_BINDINGS_SEARCH_KEY and storefronts.outlet.theme.name is
+// NRIX_STOREFRONTS_OUTLET_THEME_NAME.
+const EnvPrefix = "NRIX_"
+
+// legacyEnv maps the variables the server read before config files existed
+// to their fields. They still apply, but NRIX_* variables win. SHOW_CONTROLS
+// keeps its old meaning: only "false" hides the controls, and any other
+// value is ignored rather than rejected.
+var legacyEnv = map[string]string{
+	"API_BASE_URL":  "NRIX_API_BASE_URL",
+	"SSH_PORT":      "NRIX_SSH_PORT",
+	"SHOW_CONTROLS": "NRIX_SHOW_CONTROLS",
+}
+
+// Load builds the configuration from the defaults, the file at path (YAML,
+// TOML or JSON by extension; skipped when path is empty) and the
+// environment, in that order, and validates the result.
+func Load(path string) (*AppConfig, error) {
+	cfg := Default()
+	if path != "" {
+		if err := decodeFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+	envErr := applyEnv(cfg, os.Environ())
+	if err := errors.Join(envErr, cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func decodeFile(path string, cfg *AppConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("%s: unknown field %q", path, undecoded[0].String())
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported config format %q (want .yaml, .toml or .json)", path, ext)
+	}
+	return nil
+}
+
+// applyEnv overrides cfg from environ, a list of KEY=value pairs.
+func applyEnv(cfg *AppConfig, environ []string) error {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	for legacy, name := range legacyEnv {
+		if v, ok := env[legacy]; ok {
+			if legacy == "SHOW_CONTROLS" && v != "false" {
+				continue
+			}
+			if _, set := env[name]; !set {
+				env[name] = v
+			}
+		}
+	}
+
+	var errs []error
+	walkEnv(reflect.ValueOf(cfg).Elem(), strings.TrimSuffix(EnvPrefix, "_"), env, &errs)
+	return errors.Join(errs...)
+}
+
+func walkEnv(v reflect.Value, prefix string, env map[string]string, errs *[]error) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, fv := t.Field(i), v.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + "_" + strings.ToUpper(key)
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			walkEnv(fv, name, env, errs)
+		case reflect.Map:
+			applyEnvMap(fv, name+"_", env, errs)
+		default:
+			if s, ok := env[name]; ok {
+				if err := setScalar(fv, s); err != nil {
+					*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+				}
+			}
+		}
+	}
+}
+
+// applyEnvMap handles map fields, whose keys come from the variable names:
+// PREFIX_<KEY> for map[string]string and PREFIX_<KEY>_<FIELD> for maps of
+// structs, where FIELD may name a nested field (PREFIX_<KEY>_THEME_NAME).
+// Keys are lower-cased, so NRIX_CONTROLS_KEY_BINDINGS_ADD_TO_CART_KEY sets
+// key_bindings["add_to_cart"].key.
+func applyEnvMap(m reflect.Value, prefix string, env map[string]string, errs *[]error) {
+	elem := m.Type().Elem()
+	var leaves []envLeaf
+	if elem.Kind() == reflect.Struct {
+		leaves = envLeaves(elem, nil, "")
+	}
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		s := env[name]
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		if m.IsNil() {
+			m.Set(reflect.MakeMap(m.Type()))
+		}
+
+		if elem.Kind() != reflect.Struct {
+			val := reflect.New(elem).Elem()
+			if err := setScalar(val, s); err != nil {
+				*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.ToLower(rest)), val)
+			continue
+		}
+
+		// The longest matching field wins, so _KEYS is not read as _KEY
+		// under a map key ending in S.
+		var leaf *envLeaf
+		var mapKey string
+		for i := range leaves {
+			k, ok := strings.CutSuffix(rest, leaves[i].suffix)
+			if !ok || k == "" || leaf != nil && len(leaves[i].suffix) <= len(leaf.suffix) {
+				continue
+			}
+			leaf, mapKey = &leaves[i], k
+		}
+		if leaf == nil {
+			*errs = append(*errs, fmt.Errorf("%s: does not name a field", name))
+			continue
+		}
+		key := reflect.ValueOf(strings.ToLower(mapKey))
+		val := reflect.New(elem).Elem()
+		if cur := m.MapIndex(key); cur.IsValid() {
+			val.Set(cur)
+		}
+		if err := setScalar(val.FieldByIndex(leaf.index), s); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+		}
+		m.SetMapIndex(key, val)
+	}
+}
+
+// envLeaf is a settable field of a map's struct values: its index path and
+// the variable name suffix that addresses it, e.g. "_THEME_NAME".
+type envLeaf struct {
+	index  []int
+	suffix string
+}
+
+func envLeaves(t reflect.Type, index []int, prefix string) []envLeaf {
+	var leaves []envLeaf
+	for i := range t.NumField() {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		path := append(slices.Clone(index), i)
+		name := prefix + "_" + strings.ToUpper(key)
+		if field.Type.Kind() == reflect.Struct {
+			leaves = append(leaves, envLeaves(field.Type, path, name)...)
+			continue
+		}
+		leaves = append(leaves, envLeaf{index: path, suffix: name})
+	}
+	return leaves
+}
+
+func setScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", v.Type())
+		}
+		// Lists are comma-separated: NRIX_CONTROLS_KEY_BINDINGS_SEARCH_KEYS=s,/
+		v.Set(reflect.ValueOf(strings.Split(s, ",")))
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     []string
+		check   func(*AppConfig) any
+		want    any
+		wantErr string
+	}{
+		{
+			name:  "scalar",
+			env:   []string{"NRIX_SHOP_NAME=Outlet"},
+			check: func(c *AppConfig) any { return c.ShopName },
+			want:  "Outlet",
+		},
+		{
+			name:  "nested struct",
+			env:   []string{"NRIX_THEME_PRIMARY_COLOR=#fff"},
+			check: func(c *AppConfig) any { return c.Theme.PrimaryColor },
+			want:  "#fff",
+		},
+		{
+			name:  "bool",
+			env:   []string{"NRIX_CONTROLS_SHOW_HELP=false"},
+			check: func(c *AppConfig) any { return c.Controls.ShowHelp },
+			want:  false,
+		},
+		{
+			name:  "legacy variable",
+			env:   []string{"SSH_PORT=2022"},
+			check: func(c *AppConfig) any { return c.SSHPort },
+			want:  "2022",
+		},
+		{
+			name:  "legacy SHOW_CONTROLS=false",
+			env:   []string{"SHOW_CONTROLS=false"},
+			check: func(c *AppConfig) any { return c.ShowControls },
+			want:  false,
+		},
+		{
+			name:  "legacy SHOW_CONTROLS ignores other values",
+			env:   []string{"SHOW_CONTROLS=no"},
+			check: func(c *AppConfig) any { return c.ShowControls },
+			want:  true,
+		},
+		{
+			name:  "legacy SHOW_CONTROLS=0 leaves controls on",
+			env:   []string{"SHOW_CONTROLS=0"},
+			check: func(c *AppConfig) any { return c.ShowControls },
+			want:  true,
+		},
+		{
+			name:  "NRIX variable beats legacy",
+			env:   []string{"SSH_PORT=2022", "NRIX_SSH_PORT=2023"},
+			check: func(c *AppConfig) any { return c.SSHPort },
+			want:  "2023",
+		},
+		{
+			name:  "string map",
+			env:   []string{"NRIX_CONTROLS_CUSTOM_BINDINGS_CTRL+F=search"},
+			check: func(c *AppConfig) any { return c.Controls.CustomBindings["ctrl+f"] },
+			want:  "search",
+		},
+		{
+			name:  "struct map keeps other fields",
+			env:   []string{"NRIX_CONTROLS_KEY_BINDINGS_SEARCH_KEYS=s,f"},
+			check: func(c *AppConfig) any { return c.Controls.KeyBindings["search"] },
+			want:  KeyBinding{Key: "S", Keys: []string{"s", "f"}, Description: "Search"},
+		},
+		{
+			name:  "map key containing underscores",
+			env:   []string{"NRIX_CONTROLS_KEY_BINDINGS_ADD_TO_CART_KEY=+"},
+			check: func(c *AppConfig) any { return c.Controls.KeyBindings["add_to_cart"].Key },
+			want:  "+",
+		},
+		{
+			name:  "storefront nested field",
+			env:   []string{"NRIX_STOREFRONTS_OUTLET_THEME_NAME=light"},
+			check: func(c *AppConfig) any { return c.Storefronts["outlet"].Theme.Name },
+			want:  "light",
+		},
+		{
+			name: "storefront fields accumulate",
+			env: []string{
+				"NRIX_STOREFRONTS_OUTLET_CURRENCY_SYMBOL=$",
+				"NRIX_STOREFRONTS_OUTLET_SHOP_NAME=Outlet",
+			},
+			check: func(c *AppConfig) any {
+				sf := c.Storefronts["outlet"]
+				return []string{sf.Currency.Symbol, sf.ShopName}
+			},
+			want: []string{"$", "Outlet"},
+		},
+		{
+			name:    "unknown map field",
+			env:     []string{"NRIX_STOREFRONTS_OUTLET_COLOUR=red"},
+			wantErr: "does not name a field",
+		},
+		{
+			name:    "bad bool",
+			env:     []string{"NRIX_SHOW_CONTROLS=maybe"},
+			wantErr: "NRIX_SHOW_CONTROLS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			err := applyEnv(cfg, tt.env)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("applyEnv() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyEnv() error = %v", err)
+			}
+			if got := tt.check(cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorefrontEnvOverrideValidates(t *testing.T) {
+	cfg := Default()
+	if err := applyEnv(cfg, []string{"NRIX_STOREFRONTS_OUTLET_THEME_NAME=light"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "yaml",
+			file:    "config.yaml",
+			content: "shop_name: Outlet\ntheme:\n  primary_color: \"#ff79c6\"\n",
+		},
+		{
+			name:    "yml",
+			file:    "config.yml",
+			content: "shop_name: Outlet\ntheme:\n  primary_color: \"#ff79c6\"\n",
+		},
+		{
+			name:    "toml",
+			file:    "config.toml",
+			content: "shop_name = \"Outlet\"\n[theme]\nprimary_color = \"#ff79c6\"\n",
+		},
+		{
+			name:    "json",
+			file:    "config.JSON",
+			content: `{"shop_name": "Outlet", "theme": {"primary_color": "#ff79c6"}}`,
+		},
+		{
+			name:    "empty yaml keeps defaults",
+			file:    "config.yaml",
+			content: "",
+		},
+		{
+			name:    "unknown yaml field",
+			file:    "config.yaml",
+			content: "shop_nme: Outlet\n",
+			wantErr: "shop_nme",
+		},
+		{
+			name:    "unknown toml field",
+			file:    "config.toml",
+			content: "[theme]\nprimary_colour = \"#fff\"\n",
+			wantErr: `unknown field "theme.primary_colour"`,
+		},
+		{
+			name:    "unknown json field",
+			file:    "config.json",
+			content: `{"shop": "Outlet"}`,
+			wantErr: `unknown field "shop"`,
+		},
+		{
+			name:    "malformed",
+			file:    "config.json",
+			content: `{"shop_name": `,
+			wantErr: "config.json",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "config.ini",
+			content: "shop_name=Outlet",
+			wantErr: "unsupported config format",
+		},
+		{
+			name:    "invalid value",
+			file:    "config.yaml",
+			content: "theme:\n  name: neon\n",
+			wantErr: "neon",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			def := Default()
+			if tt.content == "" {
+				if !reflect.DeepEqual(cfg, def) {
+					t.Errorf("empty file changed the defaults")
+				}
+				return
+			}
+			if cfg.ShopName != "Outlet" || cfg.Theme.PrimaryColor != "#ff79c6" {
+				t.Errorf("ShopName = %q, PrimaryColor = %q", cfg.ShopName, cfg.Theme.PrimaryColor)
+			}
+			// Fields the file leaves out keep their defaults.
+			if cfg.SSHPort != def.SSHPort || !reflect.DeepEqual(cfg.Controls, def.Controls) {
+				t.Errorf("fields missing from the file lost their defaults")
+			}
+		})
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "shop_name: File\nssh_port: \"2300\"\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NRIX_SHOP_NAME", "Env")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ShopName != "Env" || cfg.SSHPort != "2300" {
+		t.Errorf("ShopName = %q, SSHPort = %q; want the variable over the file", cfg.ShopName, cfg.SSHPort)
+	}
+
+	t.Setenv("NRIX_SHOW_CONTROLS", "sometimes")
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "NRIX_SHOW_CONTROLS") {
+		t.Errorf("Load() error = %v, want the bad variable reported", err)
+	}
+}
+
+func TestLoadExample(t *testing.T) {
+	if _, err := Load("../../config.example.yaml"); err != nil {
+		t.Fatalf("config.example.yaml: %v", err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "none.yaml")); err == nil {
+		t.Error("Load() of a missing file succeeded")
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Overlay holds the settings a single session may change without touching
+// the shared configuration. Zero fields leave the base value alone.
+type Overlay struct {
+	// Storefront selects one of the config's storefronts; the other fields
+	// apply on top of it.
+	Storefront string
+	Theme      *ThemeConfig
+	Language   string
+	// KeyBindings replaces the keys of the actions it lists; descriptions
+	// are kept from the base config.
+	KeyBindings map[string]KeyBinding
+}
+
+// OverlayFromEnv reads session preferences from the environment an SSH
+// client sent (ssh -o SetEnv=NRIX_THEME=light). The language comes from
+// NRIX_LANGUAGE, else from the usual locale variables (LC_ALL, LC_MESSAGES,
+// LANG) that ssh forwards with SendEnv. NRIX_KEYS_<ACTION> rebinds an
+// action to a comma-separated list of keys (NRIX_KEYS_SEARCH=f,/). Unknown
+// themes, languages and actions are ignored.
+func OverlayFromEnv(environ []string) Overlay {
+	var o Overlay
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok && v != "" {
+			env[k] = v
+		}
+	}
+	if v := env["NRIX_THEME"]; slices.Contains(ThemeNames, v) {
+		o.Theme = &ThemeConfig{Name: v}
+	}
+	for _, k := range []string{"NRIX_LANGUAGE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang, ok := MatchLanguage(env[k]); ok {
+			o.Language = lang
+			break
+		}
+	}
+	for _, action := range Actions {
+		v, ok := env[keysEnvPrefix+strings.ToUpper(action)]
+		if !ok {
+			continue
+		}
+		keys := slices.DeleteFunc(strings.Split(v, ","), func(k string) bool { return k == "" })
+		if len(keys) == 0 {
+			continue
+		}
+		if o.KeyBindings == nil {
+			o.KeyBindings = make(map[string]KeyBinding)
+		}
+		o.KeyBindings[action] = KeyBinding{Keys: keys}
+	}
+	return o
+}
+
+// keysEnvPrefix starts the session variables that rebind an action.
+const keysEnvPrefix = "NRIX_KEYS_"
+
+// Clone returns a deep copy of c.
+func (c *AppConfig) Clone() *AppConfig {
+	out := *c
+	out.Controls.KeyBindings = cloneBindings(c.Controls.KeyBindings)
+	out.Controls.CustomBindings = maps.Clone(c.Controls.CustomBindings)
+	out.Storefronts = maps.Clone(c.Storefronts)
+	return &out
+}
+
+// WithOverlay returns a copy of c with o applied; c itself is unchanged.
+// Key bindings are merged per action: the overlay's keys and label replace
+// the base ones, and its description does when set.
+func (c *AppConfig) WithOverlay(o Overlay) *AppConfig {
+	out := c.Storefront(o.Storefront).Clone()
+	if o.Theme != nil {
+		out.Theme = *o.Theme
+	}
+	if o.Language != "" {
+		out.Language = o.Language
+	}
+	for action, b := range o.KeyBindings {
+		if out.Controls.KeyBindings == nil {
+			out.Controls.KeyBindings = make(map[string]KeyBinding)
+		}
+		cur := out.Controls.KeyBindings[action]
+		cur.Key = b.Key
+		cur.Keys = slices.Clone(b.Keys)
+		if b.Description != "" {
+			cur.Description = b.Description
+		}
+		out.Controls.KeyBindings[action] = cur
+	}
+	return out
+}
+
+func cloneBindings(in map[string]KeyBinding) map[string]KeyBinding {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]KeyBinding, len(in))
+	for action, b := range in {
+		b.Keys = slices.Clone(b.Keys)
+		out[action] = b
+	}
+	return out
+}
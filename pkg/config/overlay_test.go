@@ -0,0 +1,75 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOverlayFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ []string
+		want    Overlay
+	}{
+		{name: "empty", environ: nil, want: Overlay{}},
+		{
+			name:    "theme",
+			environ: []string{"NRIX_THEME=light"},
+			want:    Overlay{Theme: &ThemeConfig{Name: ThemeLight}},
+		},
+		{name: "unknown theme", environ: []string{"NRIX_THEME=neon"}, want: Overlay{}},
+		{
+			name:    "NRIX_LANGUAGE beats LANG",
+			environ: []string{"LANG=en_US.UTF-8", "NRIX_LANGUAGE=hi"},
+			want:    Overlay{Language: "hi"},
+		},
+		{
+			name:    "locale",
+			environ: []string{"LANG=C.UTF-8", "LC_ALL=hi_IN.UTF-8"},
+			want:    Overlay{Language: "hi"},
+		},
+		{
+			name:    "keys",
+			environ: []string{"NRIX_KEYS_SEARCH=f,/", "NRIX_KEYS_ADD_TO_CART=+"},
+			want: Overlay{KeyBindings: map[string]KeyBinding{
+				ActionSearch:    {Keys: []string{"f", "/"}},
+				ActionAddToCart: {Keys: []string{"+"}},
+			}},
+		},
+		{
+			name:    "unknown action and empty keys",
+			environ: []string{"NRIX_KEYS_TELEPORT=t", "NRIX_KEYS_SEARCH=,"},
+			want:    Overlay{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OverlayFromEnv(tt.environ); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OverlayFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithOverlay(t *testing.T) {
+	base := Default()
+	o := OverlayFromEnv([]string{"NRIX_THEME=light", "NRIX_LANGUAGE=hi", "NRIX_KEYS_SEARCH=f"})
+	got := base.WithOverlay(o)
+
+	if got.Theme.Name != ThemeLight || got.Language != "hi" {
+		t.Errorf("theme %q language %q, want light hi", got.Theme.Name, got.Language)
+	}
+	want := KeyBinding{Keys: []string{"f"}, Description: "Search"}
+	if b := got.Controls.KeyBindings[ActionSearch]; !reflect.DeepEqual(b, want) {
+		t.Errorf("search binding = %+v, want %+v", b, want)
+	}
+	if b := got.Controls.KeyBindings[ActionCart]; !reflect.DeepEqual(b, base.Controls.KeyBindings[ActionCart]) {
+		t.Errorf("cart binding changed to %+v", b)
+	}
+	if base.Theme.Name != ThemeDark || base.Controls.KeyBindings[ActionSearch].Keys[0] != "s" {
+		t.Error("WithOverlay modified the base config")
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
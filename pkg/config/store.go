@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Store holds the current configuration and swaps it atomically on reload.
+// Readers get an immutable snapshot from Current and never see a partially
+// applied config.
+type Store struct {
+	path string
+	cur  atomic.Pointer[AppConfig]
+	mu   sync.Mutex // serializes reloads
+}
+
+// NewStore wraps cfg, loaded from path (which may be empty), for reloading.
+func NewStore(path string, cfg *AppConfig) *Store {
+	s := &Store{path: path}
+	s.cur.Store(cfg)
+	return s
+}
+
+func (s *Store) Current() *AppConfig {
+	return s.cur.Load()
+}
+
+// Reload re-reads the config file and environment. An invalid config is
+// rejected and the current one stays in place. It returns the config that
+// was replaced along with the new one.
+func (s *Store) Reload() (prev, next *AppConfig, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err = Load(s.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.cur.Swap(next), next, nil
+}
+
+// Watch polls the config file every interval until ctx is done and calls
+// onChange whenever its size or modification time changes. It does nothing
+// when the store has no file.
+func (s *Store) Watch(ctx context.Context, interval time.Duration, onChange func()) {
+	if s.path == "" {
+		return
+	}
+	last := s.stat()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if cur := s.stat(); cur != last {
+				last = cur
+				onChange()
+			}
+		}
+	}
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func (s *Store) stat() fileStamp {
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{fi.Size(), fi.ModTime()}
+}
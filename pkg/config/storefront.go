@@ -0,0 +1,108 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+// StorefrontConfig describes one shop. Empty fields inherit the top-level
+// value, so a storefront only lists what sets it apart.
+type StorefrontConfig struct {
+	// Users are the SSH usernames routed here; when empty, the storefront
+	// name itself is the username (ssh nrix@host).
+	Users []string `yaml:"users" json:"users" toml:"users"`
+	// Port optionally gives the storefront its own listener; every session
+	// on it lands here whatever the username.
+	Port               string         `yaml:"port" json:"port" toml:"port"`
+	APIBaseURL         string         `yaml:"api_base_url" json:"api_base_url" toml:"api_base_url"`
+	ShopName           string         `yaml:"shop_name" json:"shop_name" toml:"shop_name"`
+	CompanyName        string         `yaml:"company_name" json:"company_name" toml:"company_name"`
+	CompanyDescription string         `yaml:"company_description" json:"company_description" toml:"company_description"`
+	Language           string         `yaml:"language" json:"language" toml:"language"`
+	Theme              ThemeConfig    `yaml:"theme" json:"theme" toml:"theme"`
+	Currency           CurrencyConfig `yaml:"currency" json:"currency" toml:"currency"`
+}
+
+// users returns the usernames routed to the storefront called name.
+func (s StorefrontConfig) users(name string) []string {
+	if len(s.Users) == 0 {
+		return []string{name}
+	}
+	return s.Users
+}
+
+// Route picks the storefront for a session that connected as user on port.
+// A storefront's own port wins over usernames; "" means the top-level shop.
+func (c *AppConfig) Route(user, port string) string {
+	for name, sf := range c.Storefronts {
+		if sf.Port != "" && sf.Port == port {
+			return name
+		}
+	}
+	if port != c.SSHPort {
+		return ""
+	}
+	for name, sf := range c.Storefronts {
+		if slices.Contains(sf.users(name), user) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Ports returns every port the server listens on: SSHPort first, then each
+// storefront's own port.
+func (c *AppConfig) Ports() []string {
+	ports := []string{c.SSHPort}
+	for _, name := range slices.Sorted(maps.Keys(c.Storefronts)) {
+		if p := c.Storefronts[name].Port; p != "" && !slices.Contains(ports, p) {
+			ports = append(ports, p)
+		}
+	}
+	return ports
+}
+
+// Storefront returns the effective config for the named storefront: c with
+// the storefront's non-empty fields laid over it. Theme and Currency merge
+// field by field, so a storefront that only sets currency.symbol keeps the
+// top-level grouping and decimals. Unknown names and "" return c.
+func (c *AppConfig) Storefront(name string) *AppConfig {
+	sf, ok := c.Storefronts[name]
+	if !ok {
+		return c
+	}
+	out := c.Clone()
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&out.APIBaseURL, sf.APIBaseURL)
+	set(&out.ShopName, sf.ShopName)
+	set(&out.CompanyName, sf.CompanyName)
+	set(&out.CompanyDescription, sf.CompanyDescription)
+	set(&out.Language, sf.Language)
+
+	set(&out.Theme.Name, sf.Theme.Name)
+	set(&out.Theme.PrimaryColor, sf.Theme.PrimaryColor)
+	set(&out.Theme.SecondaryColor, sf.Theme.SecondaryColor)
+	set(&out.Theme.AccentColor, sf.Theme.AccentColor)
+	set(&out.Theme.WarningColor, sf.Theme.WarningColor)
+	set(&out.Theme.ErrorColor, sf.Theme.ErrorColor)
+	set(&out.Theme.SuccessColor, sf.Theme.SuccessColor)
+	set(&out.Theme.MutedColor, sf.Theme.MutedColor)
+	set(&out.Theme.BackgroundColor, sf.Theme.BackgroundColor)
+	set(&out.Theme.HighlightColor, sf.Theme.HighlightColor)
+	set(&out.Theme.ForegroundColor, sf.Theme.ForegroundColor)
+
+	set(&out.Currency.Symbol, sf.Currency.Symbol)
+	set(&out.Currency.Code, sf.Currency.Code)
+	set(&out.Currency.Grouping, sf.Currency.Grouping)
+	set(&out.Currency.Decimals, sf.Currency.Decimals)
+	set(&out.Currency.GroupSeparator, sf.Currency.GroupSeparator)
+	set(&out.Currency.DecimalSeparator, sf.Currency.DecimalSeparator)
+	if sf.Currency.SymbolAfter {
+		out.Currency.SymbolAfter = true
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package config
+
+import "testing"
+
+func TestStorefrontMerge(t *testing.T) {
+	base := Default()
+	base.Theme.PrimaryColor = "#111111"
+	base.Storefronts = map[string]StorefrontConfig{
+		"symbol": {Currency: CurrencyConfig{Symbol: "Rs."}},
+		"light":  {ShopName: "Light", Theme: ThemeConfig{Name: ThemeLight}},
+		"after":  {Currency: CurrencyConfig{SymbolAfter: true, DecimalSeparator: ","}},
+	}
+
+	tests := []struct {
+		name       string
+		storefront string
+		wantTheme  ThemeConfig
+		wantCur    CurrencyConfig
+		wantShop   string
+	}{
+		{
+			name:       "top-level",
+			storefront: "",
+			wantTheme:  ThemeConfig{Name: ThemeDark, PrimaryColor: "#111111"},
+			wantCur:    base.Currency,
+			wantShop:   base.ShopName,
+		},
+		{
+			name:       "unknown storefront",
+			storefront: "nope",
+			wantTheme:  ThemeConfig{Name: ThemeDark, PrimaryColor: "#111111"},
+			wantCur:    base.Currency,
+			wantShop:   base.ShopName,
+		},
+		{
+			name:       "only symbol keeps grouping and decimals",
+			storefront: "symbol",
+			wantTheme:  ThemeConfig{Name: ThemeDark, PrimaryColor: "#111111"},
+			wantCur:    CurrencyConfig{Symbol: "Rs.", Code: "INR", Grouping: GroupingIndian, Decimals: DecimalsAlways},
+			wantShop:   base.ShopName,
+		},
+		{
+			name:       "theme name keeps other colors",
+			storefront: "light",
+			wantTheme:  ThemeConfig{Name: ThemeLight, PrimaryColor: "#111111"},
+			wantCur:    base.Currency,
+			wantShop:   "Light",
+		},
+		{
+			name:       "symbol after",
+			storefront: "after",
+			wantTheme:  ThemeConfig{Name: ThemeDark, PrimaryColor: "#111111"},
+			wantCur: CurrencyConfig{
+				Symbol: "₹", Code: "INR", SymbolAfter: true,
+				Grouping: GroupingIndian, Decimals: DecimalsAlways, DecimalSeparator: ",",
+			},
+			wantShop: base.ShopName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := base.Storefront(tt.storefront)
+			if got.Theme != tt.wantTheme {
+				t.Errorf("Theme = %+v, want %+v", got.Theme, tt.wantTheme)
+			}
+			if got.Currency != tt.wantCur {
+				t.Errorf("Currency = %+v, want %+v", got.Currency, tt.wantCur)
+			}
+			if got.ShopName != tt.wantShop {
+				t.Errorf("ShopName = %q, want %q", got.ShopName, tt.wantShop)
+			}
+		})
+	}
+	if base.Theme.PrimaryColor != "#111111" || base.Currency.Symbol != "₹" {
+		t.Error("Storefront modified the base config")
+	}
+}
+
+func TestRoute(t *testing.T) {
+	cfg := Default()
+	cfg.Storefronts = map[string]StorefrontConfig{
+		"outlet": {Users: []string{"outlet", "sale"}},
+		"export": {Port: "2223"},
+		"plain":  {},
+	}
+	tests := []struct {
+		user, port string
+		want       string
+	}{
+		{"sale", cfg.SSHPort, "outlet"},
+		{"plain", cfg.SSHPort, "plain"},
+		{"someone", cfg.SSHPort, ""},
+		{"sale", "2223", "export"},
+		{"export", cfg.SSHPort, "export"},
+	}
+	for _, tt := range tests {
+		if got := cfg.Route(tt.user, tt.port); got != tt.want {
+			t.Errorf("Route(%q, %q) = %q, want %q", tt.user, tt.port, got, tt.want)
+		}
+	}
+}
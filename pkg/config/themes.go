@@ -0,0 +1,92 @@
+package config
+
+// Built-in theme names.
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMonochrome   = "monochrome"
+)
+
+// ThemeNames lists the built-in themes in the order the theme key cycles
+// through them.
+var ThemeNames = []string{ThemeDark, ThemeLight, ThemeHighContrast, ThemeMonochrome}
+
+var builtinThemes = map[string]ThemeConfig{
+	// Dracula.
+	ThemeDark: {
+		PrimaryColor:    "#FF79C6",
+		SecondaryColor:  "#8BE9FD",
+		AccentColor:     "#50FA7B",
+		WarningColor:    "#FFB86C",
+		ErrorColor:      "#FF5555",
+		SuccessColor:    "#50FA7B",
+		MutedColor:      "#6272A4",
+		BackgroundColor: "#282A36",
+		HighlightColor:  "#44475A",
+		ForegroundColor: "#F8F8F2",
+	},
+	ThemeLight: {
+		PrimaryColor:    "#8250DF",
+		SecondaryColor:  "#0969DA",
+		AccentColor:     "#1A7F37",
+		WarningColor:    "#9A6700",
+		ErrorColor:      "#CF222E",
+		SuccessColor:    "#1A7F37",
+		MutedColor:      "#6E7781",
+		BackgroundColor: "#FFFFFF",
+		HighlightColor:  "#D0D7DE",
+		ForegroundColor: "#1F2328",
+	},
+	ThemeHighContrast: {
+		PrimaryColor:    "#FFFF00",
+		SecondaryColor:  "#00FFFF",
+		AccentColor:     "#00FF00",
+		WarningColor:    "#FFAF00",
+		ErrorColor:      "#FF0000",
+		SuccessColor:    "#00FF00",
+		MutedColor:      "#D0D0D0",
+		BackgroundColor: "#000000",
+		HighlightColor:  "#0000D7",
+		ForegroundColor: "#FFFFFF",
+	},
+	// Greys only; emphasis comes from weight and the highlight bar.
+	ThemeMonochrome: {
+		PrimaryColor:    "15",
+		SecondaryColor:  "250",
+		AccentColor:     "15",
+		WarningColor:    "252",
+		ErrorColor:      "15",
+		SuccessColor:    "15",
+		MutedColor:      "244",
+		BackgroundColor: "0",
+		HighlightColor:  "238",
+		ForegroundColor: "252",
+	},
+}
+
+// Resolve returns t with every empty color taken from the built-in theme it
+// names, or from the dark theme when the name is empty or unknown.
+func (t ThemeConfig) Resolve() ThemeConfig {
+	base, ok := builtinThemes[t.Name]
+	if !ok {
+		t.Name = ThemeDark
+		base = builtinThemes[ThemeDark]
+	}
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&t.PrimaryColor, base.PrimaryColor)
+	fill(&t.SecondaryColor, base.SecondaryColor)
+	fill(&t.AccentColor, base.AccentColor)
+	fill(&t.WarningColor, base.WarningColor)
+	fill(&t.ErrorColor, base.ErrorColor)
+	fill(&t.SuccessColor, base.SuccessColor)
+	fill(&t.MutedColor, base.MutedColor)
+	fill(&t.BackgroundColor, base.BackgroundColor)
+	fill(&t.HighlightColor, base.HighlightColor)
+	fill(&t.ForegroundColor, base.ForegroundColor)
+	return t
+}
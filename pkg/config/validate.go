@@ -0,0 +1,199 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// hexColor matches the #rgb and #rrggbb forms lipgloss accepts.
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Validate reports every problem with c at once, one line per field.
+func (c *AppConfig) Validate() error {
+	var errs []error
+	check := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+		}
+	}
+
+	check("api_base_url", validateURL(c.APIBaseURL))
+	check("ssh_port", validatePort(c.SSHPort))
+	check("language", validateLanguage(c.Language))
+	check("controls.help_position", oneOf(c.Controls.HelpPosition, "top", "bottom"))
+	for _, action := range slices.Sorted(maps.Keys(c.Controls.KeyBindings)) {
+		field := "controls.key_bindings." + action
+		if !slices.Contains(Actions, action) {
+			check(field, errors.New("unknown action"))
+			continue
+		}
+		b := c.Controls.KeyBindings[action]
+		if b.Key != "" && len(b.Keys) == 0 {
+			check(field+".keys", errors.New("must list at least one key when key is set"))
+		}
+		if slices.Contains(b.Keys, "") {
+			check(field+".keys", errors.New("must not contain empty keys"))
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(c.Controls.CustomBindings)) {
+		field := "controls.custom_bindings." + k
+		if k == "" {
+			check(field, errors.New("key must not be empty"))
+		}
+		if action := c.Controls.CustomBindings[k]; !slices.Contains(Actions, action) {
+			check(field, fmt.Errorf("unknown action %q", action))
+		}
+	}
+
+	validateTheme("theme", c.Theme, check)
+	validateStorefronts(c, check)
+
+	validateCurrency("currency", c.Currency, check)
+
+	if _, err := c.Audit.Mode(); err != nil {
+		check("audit.file_mode", fmt.Errorf("%q is not an octal permission", c.Audit.FileMode))
+	}
+	if c.Audit.MaxSizeMB < 0 || c.Audit.MaxBackups < 0 || c.Audit.MaxAgeDays < 0 {
+		check("audit", errors.New("sizes, backups and ages must not be negative"))
+	}
+
+	if _, err := c.Reload.PollInterval(); err != nil {
+		check("reload.interval", fmt.Errorf("%q is not a positive duration", c.Reload.Interval))
+	}
+	check("reload.sessions", oneOf(c.Reload.Sessions, "", SessionsNextScreen, SessionsSnapshot))
+
+	return errors.Join(errs...)
+}
+
+func validateLanguage(lang string) error {
+	if _, ok := MatchLanguage(lang); !ok {
+		return fmt.Errorf("%q must be one of %s", lang, strings.Join(Languages, ", "))
+	}
+	return nil
+}
+
+func validateTheme(prefix string, t ThemeConfig, check func(string, error)) {
+	check(prefix+".name", oneOf(t.Name, append([]string{""}, ThemeNames...)...))
+	for _, tc := range []struct{ field, color string }{
+		{"primary_color", t.PrimaryColor},
+		{"secondary_color", t.SecondaryColor},
+		{"accent_color", t.AccentColor},
+		{"warning_color", t.WarningColor},
+		{"error_color", t.ErrorColor},
+		{"success_color", t.SuccessColor},
+		{"muted_color", t.MutedColor},
+		{"background_color", t.BackgroundColor},
+		{"highlight_color", t.HighlightColor},
+		{"foreground_color", t.ForegroundColor},
+	} {
+		// Empty colors are filled in from the named theme.
+		if tc.color != "" {
+			check(prefix+"."+tc.field, validateColor(tc.color))
+		}
+	}
+}
+
+func validateCurrency(prefix string, cur CurrencyConfig, check func(string, error)) {
+	check(prefix+".grouping", oneOf(cur.Grouping, "", GroupingIndian, GroupingWestern, GroupingNone))
+	check(prefix+".decimals", oneOf(cur.Decimals, "", DecimalsAlways, DecimalsAuto, DecimalsNever))
+}
+
+// validateStorefronts checks each storefront and that no SSH user or port
+// routes to two places.
+func validateStorefronts(c *AppConfig, check func(string, error)) {
+	users := make(map[string]string)
+	ports := map[string]string{c.SSHPort: "ssh_port"}
+	for _, name := range slices.Sorted(maps.Keys(c.Storefronts)) {
+		sf := c.Storefronts[name]
+		prefix := "storefronts." + name
+		if strings.TrimSpace(name) == "" {
+			check("storefronts", errors.New("storefront names must not be empty"))
+		}
+		if sf.APIBaseURL != "" {
+			check(prefix+".api_base_url", validateURL(sf.APIBaseURL))
+		}
+		if sf.Language != "" {
+			check(prefix+".language", validateLanguage(sf.Language))
+		}
+		validateTheme(prefix+".theme", sf.Theme, check)
+		validateCurrency(prefix+".currency", sf.Currency, check)
+		for _, u := range sf.users(name) {
+			if other, ok := users[u]; ok {
+				check(prefix+".users", fmt.Errorf("user %q is already routed to %s", u, other))
+				continue
+			}
+			users[u] = prefix
+		}
+		if sf.Port != "" {
+			check(prefix+".port", validatePort(sf.Port))
+			if other, ok := ports[sf.Port]; ok {
+				check(prefix+".port", fmt.Errorf("port %s is already used by %s", sf.Port, other))
+			}
+			ports[sf.Port] = prefix
+		}
+	}
+}
+
+// validateURL accepts the same forms the API client does: an http(s) URL,
+// or a bare host[:port] that is treated as http.
+func validateURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return errors.New("must not be empty")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL", raw)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme %q is not http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", raw)
+	}
+	return nil
+}
+
+func validatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("%q is not a port between 1 and 65535", port)
+	}
+	return nil
+}
+
+// validateColor accepts an ANSI 256 color index or a hex color.
+func validateColor(color string) error {
+	if n, err := strconv.Atoi(color); err == nil {
+		if n < 0 || n > 255 {
+			return fmt.Errorf("ANSI color %d is out of range 0-255", n)
+		}
+		return nil
+	}
+	if !hexColor.MatchString(color) {
+		return fmt.Errorf("%q is not an ANSI color index or #rrggbb hex color", color)
+	}
+	return nil
+}
+
+func oneOf(value string, allowed ...string) error {
+	if slices.Contains(allowed, value) {
+		return nil
+	}
+	var names []string
+	for _, a := range allowed {
+		if a != "" {
+			names = append(names, strconv.Quote(a))
+		}
+	}
+	return fmt.Errorf("%q must be one of %s", value, strings.Join(names, ", "))
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*AppConfig)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(*AppConfig) {}},
+		{
+			name:    "bad port",
+			modify:  func(c *AppConfig) { c.SSHPort = "70000" },
+			wantErr: "ssh_port",
+		},
+		{
+			name:    "unknown language",
+			modify:  func(c *AppConfig) { c.Language = "fr" },
+			wantErr: "language",
+		},
+		{
+			name:    "bad grouping",
+			modify:  func(c *AppConfig) { c.Currency.Grouping = "bogus" },
+			wantErr: "currency.grouping",
+		},
+		{
+			name: "storefront bad grouping",
+			modify: func(c *AppConfig) {
+				c.Storefronts = map[string]StorefrontConfig{"outlet": {Currency: CurrencyConfig{Grouping: "bogus"}}}
+			},
+			wantErr: "storefronts.outlet.currency.grouping",
+		},
+		{
+			name: "storefront bad decimals",
+			modify: func(c *AppConfig) {
+				c.Storefronts = map[string]StorefrontConfig{"outlet": {Currency: CurrencyConfig{Decimals: "some"}}}
+			},
+			wantErr: "storefronts.outlet.currency.decimals",
+		},
+		{
+			name: "storefront partial currency",
+			modify: func(c *AppConfig) {
+				c.Storefronts = map[string]StorefrontConfig{"outlet": {Currency: CurrencyConfig{Symbol: "$"}}}
+			},
+		},
+		{
+			name: "storefront bad color",
+			modify: func(c *AppConfig) {
+				c.Storefronts = map[string]StorefrontConfig{"outlet": {Theme: ThemeConfig{PrimaryColor: "pink"}}}
+			},
+			wantErr: "storefronts.outlet.theme.primary_color",
+		},
+		{
+			name: "user routed twice",
+			modify: func(c *AppConfig) {
+				c.Storefronts = map[string]StorefrontConfig{
+					"a": {Users: []string{"shop"}},
+					"b": {Users: []string{"shop"}},
+				}
+			},
+			wantErr: "already routed",
+		},
+		{
+			name: "port shared with ssh_port",
+			modify: func(c *AppConfig) {
+				c.Storefronts = map[string]StorefrontConfig{"a": {Port: c.SSHPort}}
+			},
+			wantErr: "already used",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package types
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 type Media struct {
 	URL      string `json:"url"`
@@ -37,8 +41,8 @@ type Product struct {
 	Brand             string           `json:"brand"`
 	Categories        []string         `json:"categories"`
 	ProductDescription string          `json:"product_description"`
-	MRPPrice          float64          `json:"mrp_price"`
-	SellingPrice      float64          `json:"selling_price"`
+	MRPPrice          Money            `json:"mrp_price"`
+	SellingPrice      Money            `json:"selling_price"`
 	Tags              []string         `json:"tags"`
 	Medias            []Media          `json:"medias"`
 	Features          []string         `json:"features"`
@@ -47,6 +51,32 @@ type Product struct {
 	CategoryDetails   []CategoryDetail  `json:"category_details"`
 }
 
+// Available reports whether the product can be bought with variant: it is
+// active, and variant picks an active value for each of its options and
+// nothing else.
+func (p Product) Available(variant map[string]string) bool {
+	if !p.Active || len(variant) != len(p.ProductVariants) {
+		return false
+	}
+	for _, pv := range p.ProductVariants {
+		label, ok := variant[pv.VariantName]
+		if !ok {
+			return false
+		}
+		found := false
+		for _, v := range pv.VariantValues {
+			if v.Label == label && v.Active {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 type Category struct {
 	ID          string   `json:"_id"`
 	Name        string   `json:"name"`
@@ -90,8 +120,8 @@ type ShippingDetails struct {
 
 type Order struct {
 	ID              string          `json:"_id"`
-	TotalAmount     float64         `json:"total_amount"`
-	TotalDiscount   float64         `json:"total_discount"`
+	TotalAmount     Money           `json:"total_amount"`
+	TotalDiscount   Money           `json:"total_discount"`
 	OrderItems      []OrderItem     `json:"order_items"`
 	ShippingDetails ShippingDetails `json:"shipping_details"`
 	Status          OrderStatus     `json:"status"`
@@ -103,10 +133,12 @@ type APIRequest struct {
 	Params    interface{}   `json:"params"`
 }
 
+// APIResponse keeps Data raw so callers decode it once into the type the
+// operation returns.
 type APIResponse struct {
-	Data  interface{} `json:"data"`
-	Count int         `json:"count,omitempty"`
-	Error string      `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data"`
+	Count int             `json:"count,omitempty"`
+	Error string          `json:"error,omitempty"`
 }
 
 type ProductListParams struct {
@@ -133,8 +165,8 @@ type ProductCreateParams struct {
 	Brand             string           `json:"brand"`
 	Categories        []string         `json:"categories"`
 	ProductDescription string          `json:"product_description"`
-	MRPPrice          float64          `json:"mrp_price"`
-	SellingPrice      float64          `json:"selling_price"`
+	MRPPrice          Money            `json:"mrp_price"`
+	SellingPrice      Money            `json:"selling_price"`
 	Tags              []string         `json:"tags"`
 	Medias            []Media          `json:"medias"`
 	Features          []string         `json:"features"`
@@ -148,8 +180,8 @@ type ProductUpdateParams struct {
 	Brand             *string          `json:"brand,omitempty"`
 	Categories        []string         `json:"categories,omitempty"`
 	ProductDescription *string         `json:"product_description,omitempty"`
-	MRPPrice          *float64         `json:"mrp_price,omitempty"`
-	SellingPrice      *float64         `json:"selling_price,omitempty"`
+	MRPPrice          *Money           `json:"mrp_price,omitempty"`
+	SellingPrice      *Money           `json:"selling_price,omitempty"`
 	Tags              []string         `json:"tags,omitempty"`
 	Medias            []Media          `json:"medias,omitempty"`
 	Features          []string         `json:"features,omitempty"`
@@ -206,6 +238,9 @@ type OrderCreateParams struct {
 	UserEmail       string               `json:"userEmail"`
 	Timestamp       string               `json:"timestamp"`
 	PaymentMethod   string               `json:"paymentMethod,omitempty"`
+	// IdempotencyKey is reused on every retry of the same checkout so the
+	// backend can deduplicate; it is also sent as the Idempotency-Key header.
+	IdempotencyKey  string               `json:"idempotencyKey,omitempty"`
 }
 
 type OrderItemInput struct {
@@ -213,15 +248,15 @@ type OrderItemInput struct {
 	ProductName string            `json:"productName"`
 	Variant     map[string]string `json:"variant"`
 	Quantity    int               `json:"quantity"`
-	Price       float64           `json:"price"`
-	Total       float64           `json:"total"`
+	Price       Money             `json:"price"`
+	Total       Money             `json:"total"`
 }
 
 type OrderPricingInput struct {
-	Subtotal float64 `json:"subtotal"`
-	Discount float64 `json:"discount"`
-	Shipping float64 `json:"shipping"`
-	Total    float64 `json:"total"`
+	Subtotal Money `json:"subtotal"`
+	Discount Money `json:"discount"`
+	Shipping Money `json:"shipping"`
+	Total    Money `json:"total"`
 }
 
 type OrderUpdateStatusParams struct {
@@ -230,23 +265,35 @@ type OrderUpdateStatusParams struct {
 }
 
 type CartItem struct {
-	Product  Product
-	Quantity int
-	Variant  map[string]string
+	Product  Product           `json:"product"`
+	Quantity int               `json:"quantity"`
+	Variant  map[string]string `json:"variant,omitempty"`
 }
 
 type Cart struct {
-	Items []CartItem
+	Items []CartItem `json:"items"`
 }
 
-func (c *Cart) Total() float64 {
-	total := 0.0
+// Total returns the line total: the selling price times the quantity.
+func (i CartItem) Total() Money {
+	return i.Product.SellingPrice.Mul(i.Quantity)
+}
+
+func (c *Cart) Total() Money {
+	var total Money
 	for _, item := range c.Items {
-		total += item.Product.SellingPrice * float64(item.Quantity)
+		total = total.Add(item.Total())
 	}
 	return total
 }
 
+// MaxCartQuantity caps how many of one item a cart may hold.
+const MaxCartQuantity = 5
+
+// ErrQuantityLimit is wrapped by cart changes that were capped at
+// MaxCartQuantity.
+var ErrQuantityLimit = errors.New("quantity limit reached")
+
 func (c *Cart) Count() int {
 	count := 0
 	for _, item := range c.Items {
@@ -256,29 +303,28 @@ func (c *Cart) Count() int {
 }
 
 func (c *Cart) Add(product Product, quantity int, variant map[string]string) error {
-	const maxQuantity = 5
 	for i := range c.Items {
 		if c.Items[i].Product.ID == product.ID && variantsEqual(c.Items[i].Variant, variant) {
 			newQuantity := c.Items[i].Quantity + quantity
-			if newQuantity > maxQuantity {
-				c.Items[i].Quantity = maxQuantity
-				return fmt.Errorf("maximum quantity of %d reached for this item", maxQuantity)
+			if newQuantity > MaxCartQuantity {
+				c.Items[i].Quantity = MaxCartQuantity
+				return fmt.Errorf("%w: maximum quantity of %d reached for this item", ErrQuantityLimit, MaxCartQuantity)
 			}
 			c.Items[i].Quantity = newQuantity
 			return nil
 		}
 	}
 	originalQuantity := quantity
-	if quantity > maxQuantity {
-		quantity = maxQuantity
+	if quantity > MaxCartQuantity {
+		quantity = MaxCartQuantity
 	}
 	c.Items = append(c.Items, CartItem{
 		Product:  product,
 		Quantity: quantity,
 		Variant:  copyVariantMap(variant),
 	})
-	if originalQuantity > maxQuantity {
-		return fmt.Errorf("quantity limited to maximum of %d", maxQuantity)
+	if originalQuantity > MaxCartQuantity {
+		return fmt.Errorf("%w: quantity limited to maximum of %d", ErrQuantityLimit, MaxCartQuantity)
 	}
 	return nil
 }
@@ -293,16 +339,15 @@ func (c *Cart) Remove(productID string, variant map[string]string) {
 }
 
 func (c *Cart) UpdateQuantity(productID string, variant map[string]string, quantity int) error {
-	const maxQuantity = 5
 	for i := range c.Items {
 		if c.Items[i].Product.ID == productID && variantsEqual(c.Items[i].Variant, variant) {
 			if quantity <= 0 {
 				c.Remove(productID, variant)
 				return nil
 			}
-			if quantity > maxQuantity {
-				c.Items[i].Quantity = maxQuantity
-				return fmt.Errorf("maximum quantity of %d reached for this item", maxQuantity)
+			if quantity > MaxCartQuantity {
+				c.Items[i].Quantity = MaxCartQuantity
+				return fmt.Errorf("%w: maximum quantity of %d reached for this item", ErrQuantityLimit, MaxCartQuantity)
 			}
 			c.Items[i].Quantity = quantity
 			return nil
@@ -0,0 +1,35 @@
+package types
+
+import "testing"
+
+func inr(minor int64) Money { return NewMoney(minor, "") }
+
+func TestCartTotal(t *testing.T) {
+	cart := Cart{Items: []CartItem{
+		{Product: Product{ID: "tee", SellingPrice: inr(79900)}, Quantity: 2},
+		{Product: Product{ID: "sticker", SellingPrice: inr(14950)}, Quantity: 3},
+	}}
+	if got, want := cart.Total().Minor, int64(204650); got != want {
+		t.Errorf("Total = %d, want %d", got, want)
+	}
+	if got := (&Cart{}).Total(); !got.IsZero() {
+		t.Errorf("empty cart Total = %v", got)
+	}
+}
+
+func TestCartAdd(t *testing.T) {
+	p := Product{ID: "tee", SellingPrice: inr(79900)}
+	var cart Cart
+	if err := cart.Add(p, 2, map[string]string{"Size": "M"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cart.Add(p, 1, map[string]string{"Size": "L"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cart.Add(p, 4, map[string]string{"Size": "M"}); err == nil {
+		t.Error("Add past MaxCartQuantity succeeded")
+	}
+	if len(cart.Items) != 2 || cart.Items[0].Quantity != MaxCartQuantity || cart.Count() != MaxCartQuantity+1 {
+		t.Errorf("cart = %+v", cart.Items)
+	}
+}
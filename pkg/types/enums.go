@@ -20,6 +20,17 @@ const (
 	OrderStatusInHub           OrderStatusType = "in_hub"
 )
 
+// Valid reports whether s is one of the statuses the backend understands.
+func (s OrderStatusType) Valid() bool {
+	switch s {
+	case OrderStatusAccepted, OrderStatusRejected, OrderStatusRejectedByUser,
+		OrderStatusDelivered, OrderStatusOutForDelivery, OrderStatusAgent,
+		OrderStatusAgentChanged, OrderStatusInHub:
+		return true
+	}
+	return false
+}
+
 type DiscountType string
 
 const (
@@ -37,4 +48,5 @@ const (
 	ScreenAddress
 	ScreenCheckout
 	ScreenOrderSuccess
+	ScreenOrders
 )
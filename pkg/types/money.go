@@ -0,0 +1,206 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for amounts that arrive without a currency,
+// which is every amount the backend sends.
+const DefaultCurrency = "INR"
+
+// currencyDigits lists the ISO 4217 minor-unit exponent for currencies that
+// don't use two decimal places.
+var currencyDigits = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// Money is an exact amount in integer minor units (paise for INR). On the
+// wire it is a plain JSON number in major units, as the backend expects.
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+// ParseMoney parses a decimal amount in major units, such as "1999.50".
+// Digits beyond the currency's precision are rounded half away from zero.
+func ParseMoney(s, currency string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	m := Money{Currency: currency}
+	minor, ok := roundRat(r.Mul(r, new(big.Rat).SetInt64(m.scale())))
+	if !ok {
+		return Money{}, fmt.Errorf("amount %q out of range", s)
+	}
+	m.Minor = minor
+	return m, nil
+}
+
+// Code returns the currency code, falling back to DefaultCurrency.
+func (m Money) Code() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// Digits returns the number of minor-unit decimal places for the currency.
+func (m Money) Digits() int {
+	if d, ok := currencyDigits[m.Code()]; ok {
+		return d
+	}
+	return 2
+}
+
+func (m Money) scale() int64 {
+	s := int64(1)
+	for range m.Digits() {
+		s *= 10
+	}
+	return s
+}
+
+func (m Money) IsZero() bool { return m.Minor == 0 }
+
+// Add returns m+o. Adding amounts in different currencies is a programming
+// error and panics.
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+	if m.Currency == "" {
+		m.Currency = o.Currency
+	}
+	m.Minor += o.Minor
+	return m
+}
+
+// Sub returns m-o; see Add.
+func (m Money) Sub(o Money) Money {
+	return m.Add(Money{Minor: -o.Minor, Currency: o.Currency})
+}
+
+// Mul returns m multiplied by a whole quantity.
+func (m Money) Mul(n int) Money {
+	m.Minor *= int64(n)
+	return m
+}
+
+// Percent returns rate percent of m, rounded half away from zero to the
+// nearest minor unit.
+func (m Money) Percent(rate float64) Money {
+	r := new(big.Rat).SetInt64(m.Minor)
+	r.Mul(r, ratFromFloat(rate))
+	r.Quo(r, big.NewRat(100, 1))
+	m.Minor, _ = roundRat(r)
+	return m
+}
+
+// Cmp compares m and o, returning -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Minor < o.Minor:
+		return -1
+	case m.Minor > o.Minor:
+		return 1
+	}
+	return 0
+}
+
+// Major returns the amount in major units. It is meant for display
+// calculations such as percentages, never for arithmetic on amounts.
+func (m Money) Major() float64 {
+	return float64(m.Minor) / float64(m.scale())
+}
+
+// Decimal formats the amount in major units with the currency's full
+// precision and no grouping, e.g. "125000.00".
+func (m Money) Decimal() string {
+	digits := m.Digits()
+	neg := m.Minor < 0
+	minor := m.Minor
+	if neg {
+		minor = -minor
+	}
+	s := strconv.FormatUint(uint64(minor), 10)
+	if digits > 0 {
+		if len(s) <= digits {
+			s = strings.Repeat("0", digits-len(s)+1) + s
+		}
+		s = s[:len(s)-digits] + "." + s[len(s)-digits:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Code()
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, or a number in a string, in major
+// units. The currency is left unset and reads as DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(s, m.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) mustMatch(o Money) {
+	if m.Currency != "" && o.Currency != "" && m.Currency != o.Currency {
+		panic(fmt.Sprintf("types: mixing %s and %s amounts", m.Currency, o.Currency))
+	}
+}
+
+// ratFromFloat converts through the shortest decimal representation so that
+// 12.5 means exactly 12.5 rather than its nearest binary fraction.
+func ratFromFloat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	return r
+}
+
+func roundRat(r *big.Rat) (int64, bool) {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+	neg := num.Sign() < 0
+	num.Abs(num)
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if neg {
+		q.Neg(q)
+	}
+	return q.Int64(), q.IsInt64()
+}
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in       string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{"1999.50", "", 199950, false},
+		{" 12 ", "", 1200, false},
+		{"0.005", "", 1, false},
+		{"0.004", "", 0, false},
+		{"-0.005", "", -1, false},
+		{"1e3", "", 100000, false},
+		{"1999.5", "JPY", 2000, false},
+		{"1.2345", "KWD", 1235, false},
+		{"abc", "", 0, true},
+		{"", "", 0, true},
+		{"1e30", "", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in, tt.currency)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMoney(%q) error = %v", tt.in, err)
+			continue
+		}
+		if !tt.wantErr && (got.Minor != tt.want || got.Currency != tt.currency) {
+			t.Errorf("ParseMoney(%q, %q) = %+v, want %d", tt.in, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{NewMoney(12500000, ""), "125000.00"},
+		{NewMoney(5, "INR"), "0.05"},
+		{NewMoney(0, ""), "0.00"},
+		{NewMoney(-1, ""), "-0.01"},
+		{NewMoney(-12345, "USD"), "-123.45"},
+		{NewMoney(1500, "JPY"), "1500"},
+		{NewMoney(1234, "KWD"), "1.234"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Decimal(); got != tt.want {
+			t.Errorf("%+v.Decimal() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+	if got := NewMoney(199950, "").String(); got != "1999.50 INR" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{`1999.5`, 199950, false},
+		{`"1999.50"`, 199950, false},
+		{`0`, 0, false},
+		{`null`, 0, false},
+		{`"abc"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, tt := range tests {
+		var m Money
+		err := json.Unmarshal([]byte(tt.in), &m)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("unmarshal %s: error = %v", tt.in, err)
+			continue
+		}
+		if m.Minor != tt.want {
+			t.Errorf("unmarshal %s = %d, want %d", tt.in, m.Minor, tt.want)
+		}
+	}
+
+	out, err := json.Marshal(struct {
+		Price Money `json:"price"`
+	}{NewMoney(199950, "")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"price":1999.50}` {
+		t.Errorf("marshal = %s", out)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"add", inr(150).Add(inr(250)), inr(400)},
+		{"add picks up currency", NewMoney(100, "").Add(NewMoney(1, "USD")), NewMoney(101, "USD")},
+		{"sub", inr(150).Sub(inr(250)), inr(-100)},
+		{"mul", inr(1999).Mul(3), inr(5997)},
+		{"percent", inr(79900).Percent(10), inr(7990)},
+		{"percent rounds half up", inr(5).Percent(50), inr(3)},
+		{"percent of negative", inr(-5).Percent(50), inr(-3)},
+		{"fractional rate", inr(10000).Percent(12.5), inr(1250)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	cmps := []struct {
+		a, b Money
+		want int
+	}{
+		{inr(1), inr(2), -1},
+		{inr(2), inr(2), 0},
+		{inr(3), NewMoney(2, "INR"), 1},
+	}
+	for _, tt := range cmps {
+		if got := tt.a.Cmp(tt.b); got != tt.want {
+			t.Errorf("%v.Cmp(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	ops := map[string]func(a, b Money){
+		"Add": func(a, b Money) { a.Add(b) },
+		"Sub": func(a, b Money) { a.Sub(b) },
+		"Cmp": func(a, b Money) { a.Cmp(b) },
+	}
+	for name, op := range ops {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s of INR and USD did not panic", name)
+				}
+			}()
+			op(NewMoney(1, "INR"), NewMoney(1, "USD"))
+		}()
+	}
+}
+
+func TestMoneyDigits(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     int
+		major    float64
+	}{
+		{"", 2, 12.34},
+		{"USD", 2, 12.34},
+		{"JPY", 0, 1234},
+		{"KWD", 3, 1.234},
+	}
+	for _, tt := range tests {
+		m := NewMoney(1234, tt.currency)
+		if m.Digits() != tt.want || m.Major() != tt.major {
+			t.Errorf("%q: digits %d, major %v", tt.currency, m.Digits(), m.Major())
+		}
+	}
+}